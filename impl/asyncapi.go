@@ -0,0 +1,150 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	asyncAPIActionSend    = "send"
+	asyncAPIActionReceive = "receive"
+
+	asyncAPIChannelsRef = "#/channels/"
+	asyncAPIServersRef  = "#/servers/"
+)
+
+// asyncAPIDocument holds the parts of an AsyncAPI 3.x document needed to perform its operations.
+type asyncAPIDocument struct {
+	AsyncAPI   string                       `json:"asyncapi"`
+	Servers    map[string]asyncAPIServer    `json:"servers,omitempty"`
+	Channels   map[string]asyncAPIChannel   `json:"channels,omitempty"`
+	Operations map[string]asyncAPIOperation `json:"operations,omitempty"`
+}
+
+type asyncAPIServer struct {
+	Host      string                            `json:"host"`
+	Protocol  string                            `json:"protocol"`
+	Pathname  string                            `json:"pathname,omitempty"`
+	Variables map[string]asyncAPIServerVariable `json:"variables,omitempty"`
+}
+
+type asyncAPIServerVariable struct {
+	Default string `json:"default,omitempty"`
+}
+
+type asyncAPIChannel struct {
+	Address *string       `json:"address,omitempty"`
+	Servers []asyncAPIRef `json:"servers,omitempty"`
+}
+
+type asyncAPIOperation struct {
+	Action  string      `json:"action"`
+	Channel asyncAPIRef `json:"channel"`
+}
+
+type asyncAPIRef struct {
+	Ref string `json:"$ref"`
+}
+
+// asyncAPIOperationRef is an operation resolved against a document: what to do, on which channel of which server.
+type asyncAPIOperationRef struct {
+	Action     string
+	Address    string
+	ServerName string
+	Server     *asyncAPIServer
+}
+
+func parseAsyncAPIDocument(source []byte) (*asyncAPIDocument, error) {
+	doc := &asyncAPIDocument{}
+	if err := yaml.Unmarshal(source, doc); err != nil {
+		return nil, fmt.Errorf("invalid AsyncAPI document: %w", err)
+	}
+	if !strings.HasPrefix(doc.AsyncAPI, "3.") {
+		return nil, fmt.Errorf("unsupported AsyncAPI version '%s', only 3.x documents are supported", doc.AsyncAPI)
+	}
+	return doc, nil
+}
+
+// findOperation resolves the operation, or the channel when no operation is named, and the server to reach it on:
+// the named server, the first server of the channel, or the first server of the document by name. Without an
+// operation, the action is given by the caller.
+func (d *asyncAPIDocument) findOperation(operationName, channelName, serverName, action string) (*asyncAPIOperationRef, error) {
+	ref := &asyncAPIOperationRef{Action: action}
+	if operationName != "" {
+		operation, ok := d.Operations[operationName]
+		if !ok {
+			return nil, fmt.Errorf("operation '%s' not found in the AsyncAPI document", operationName)
+		}
+		if operation.Action != asyncAPIActionSend && operation.Action != asyncAPIActionReceive {
+			return nil, fmt.Errorf("operation '%s' has unsupported action '%s'", operationName, operation.Action)
+		}
+		ref.Action = operation.Action
+		if !strings.HasPrefix(operation.Channel.Ref, asyncAPIChannelsRef) {
+			return nil, fmt.Errorf("operation '%s' has unresolvable channel reference '%s'", operationName, operation.Channel.Ref)
+		}
+		channelName = strings.TrimPrefix(operation.Channel.Ref, asyncAPIChannelsRef)
+	}
+	if channelName == "" {
+		return nil, fmt.Errorf("either an operation or a channel must be set")
+	}
+	channel, ok := d.Channels[channelName]
+	if !ok {
+		return nil, fmt.Errorf("channel '%s' not found in the AsyncAPI document", channelName)
+	}
+	ref.Address = channelName
+	if channel.Address != nil {
+		ref.Address = *channel.Address
+	}
+
+	if serverName == "" && len(channel.Servers) > 0 {
+		serverName = strings.TrimPrefix(channel.Servers[0].Ref, asyncAPIServersRef)
+	}
+	if serverName == "" && len(d.Servers) > 0 {
+		names := make([]string, 0, len(d.Servers))
+		for name := range d.Servers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		serverName = names[0]
+	}
+	if serverName != "" {
+		server, ok := d.Servers[serverName]
+		if !ok {
+			return nil, fmt.Errorf("server '%s' not found in the AsyncAPI document", serverName)
+		}
+		ref.ServerName = serverName
+		ref.Server = &server
+	}
+	return ref, nil
+}
+
+// substituteVariables replaces the `{variable}` placeholders with the given values, or the declared defaults.
+func (s *asyncAPIServer) substituteVariables(value string, values map[string]interface{}) string {
+	return uriTemplateVarPattern.ReplaceAllStringFunc(value, func(match string) string {
+		name := match[1 : len(match)-1]
+		if v, ok := values[name]; ok {
+			return fmt.Sprintf("%v", v)
+		}
+		if variable, ok := s.Variables[name]; ok {
+			return variable.Default
+		}
+		return match
+	})
+}
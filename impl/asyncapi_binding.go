@@ -0,0 +1,124 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/serverlessworkflow/sdk-go/v3/impl/utils"
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+)
+
+// ErrAsyncAPISubscriptionClosed is returned when receiving from a closed AsyncAPI subscription.
+var ErrAsyncAPISubscriptionClosed = errors.New("asyncapi subscription closed")
+
+// AsyncAPIBinding carries the messages of `call: asyncapi` tasks over a protocol, e.g. Kafka, MQTT or AMQP.
+type AsyncAPIBinding interface {
+	// Publish sends the message to the channel of the endpoint.
+	Publish(ctx context.Context, endpoint AsyncAPIEndpoint, message *AsyncAPIMessage) error
+	// Subscribe starts receiving the messages sent to the channel of the endpoint, until the subscription is closed.
+	Subscribe(ctx context.Context, endpoint AsyncAPIEndpoint) (AsyncAPISubscription, error)
+}
+
+// AsyncAPISubscription is an open stream of messages.
+type AsyncAPISubscription interface {
+	Messages() <-chan *AsyncAPIMessage
+	Close() error
+}
+
+// AsyncAPIEndpoint is the resolved location of an AsyncAPI operation.
+type AsyncAPIEndpoint struct {
+	Protocol string
+	// Host and Pathname are those of the server, with its variables substituted.
+	Host     string
+	Pathname string
+	// Channel is the address of the channel.
+	Channel string
+	// Authentication is the policy to authenticate with, its runtime expressions evaluated. Nil when none is set.
+	Authentication *model.AuthenticationPolicy
+}
+
+// AsyncAPIMessage is a message sent or received through a channel.
+type AsyncAPIMessage struct {
+	Payload interface{}
+	Headers map[string]interface{}
+}
+
+func (m *AsyncAPIMessage) toMap() map[string]interface{} {
+	headers := m.Headers
+	if headers == nil {
+		headers = map[string]interface{}{}
+	}
+	return map[string]interface{}{"payload": m.Payload, "headers": headers}
+}
+
+var _ AsyncAPIBinding = &MemoryAsyncAPIBinding{}
+
+// memoryAsyncAPIBuffer is how many messages a subscription holds before publishing blocks.
+const memoryAsyncAPIBuffer = 128
+
+// MemoryAsyncAPIBinding is an in-process broker: a message published to a channel of a host is delivered to all the
+// subscriptions open on it. It lets workflows calling AsyncAPI operations be run, and tested, without an actual broker.
+type MemoryAsyncAPIBinding struct {
+	mu       sync.Mutex
+	channels map[string]*utils.FanOut[*AsyncAPIMessage]
+}
+
+func NewMemoryAsyncAPIBinding() *MemoryAsyncAPIBinding {
+	return &MemoryAsyncAPIBinding{channels: map[string]*utils.FanOut[*AsyncAPIMessage]{}}
+}
+
+// Publish delivers the message to every subscription open on the channel, waiting for room in the ones that are full.
+func (b *MemoryAsyncAPIBinding) Publish(ctx context.Context, endpoint AsyncAPIEndpoint, message *AsyncAPIMessage) error {
+	return b.channel(endpoint).Publish(ctx, message)
+}
+
+func (b *MemoryAsyncAPIBinding) Subscribe(ctx context.Context, endpoint AsyncAPIEndpoint) (AsyncAPISubscription, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return &memoryAsyncAPISubscription{b.channel(endpoint).Subscribe()}, nil
+}
+
+// Subscribers returns the number of subscriptions open on the channel, e.g. to wait until a workflow is listening.
+func (b *MemoryAsyncAPIBinding) Subscribers(endpoint AsyncAPIEndpoint) int {
+	return b.channel(endpoint).Subscribers()
+}
+
+// channel returns the fan-out of the channel of the endpoint, created on first use.
+func (b *MemoryAsyncAPIBinding) channel(endpoint AsyncAPIEndpoint) *utils.FanOut[*AsyncAPIMessage] {
+	key := endpoint.Host + endpoint.Pathname + "#" + endpoint.Channel
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.channels[key] == nil {
+		b.channels[key] = utils.NewFanOut[*AsyncAPIMessage](memoryAsyncAPIBuffer)
+	}
+	return b.channels[key]
+}
+
+type memoryAsyncAPISubscription struct {
+	*utils.FanOutSubscription[*AsyncAPIMessage]
+}
+
+func (s *memoryAsyncAPISubscription) Messages() <-chan *AsyncAPIMessage {
+	return s.Values()
+}
+
+func (s *memoryAsyncAPISubscription) Close() error {
+	s.FanOutSubscription.Close()
+	return nil
+}
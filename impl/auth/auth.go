@@ -0,0 +1,191 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package auth applies the workflow authentication policies to outgoing requests.
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+)
+
+var (
+	// ErrPolicyNotFound is returned when a `use` reference does not match any `use.authentications` entry.
+	ErrPolicyNotFound = errors.New("authentication policy not found")
+	// ErrUnsupportedPolicy is returned for policies this implementation cannot apply yet.
+	ErrUnsupportedPolicy = errors.New("unsupported authentication policy")
+)
+
+// ResolvePolicy returns the inline policy of the given reference or the one named by `use` in the workflow's
+// `use.authentications`. A nil reference resolves to a nil policy.
+func ResolvePolicy(ref *model.ReferenceableAuthenticationPolicy, workflow *model.Workflow) (*model.AuthenticationPolicy, error) {
+	if ref == nil {
+		return nil, nil
+	}
+	if ref.Use == nil {
+		if ref.AuthenticationPolicy == nil {
+			return nil, fmt.Errorf("%w: authentication must be either inline or a reference", ErrUnsupportedPolicy)
+		}
+		return ref.AuthenticationPolicy, nil
+	}
+	if workflow != nil && workflow.Use != nil {
+		if policy, ok := workflow.Use.Authentications[*ref.Use]; ok && policy != nil {
+			return policy, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: '%s' is not defined in 'use.authentications'", ErrPolicyNotFound, *ref.Use)
+}
+
+// NewTransport wraps next with a http.RoundTripper that authenticates every request according to the policy.
+// OAuth2 and OpenID Connect tokens are acquired and cached by the given provider; a nil provider uses a new one.
+func NewTransport(policy *model.AuthenticationPolicy, next http.RoundTripper, provider *TokenProvider) (http.RoundTripper, error) {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	switch {
+	case policy == nil:
+		return next, nil
+	case policy.Basic != nil:
+		if policy.Basic.Use != "" {
+			return nil, fmt.Errorf("%w: basic credentials from secret '%s'", ErrUnsupportedPolicy, policy.Basic.Use)
+		}
+		return &basicTransport{username: policy.Basic.Username, password: policy.Basic.Password, next: next}, nil
+	case policy.Bearer != nil:
+		if policy.Bearer.Use != "" {
+			return nil, fmt.Errorf("%w: bearer token from secret '%s'", ErrUnsupportedPolicy, policy.Bearer.Use)
+		}
+		return &bearerTransport{token: policy.Bearer.Token, next: next}, nil
+	case policy.Digest != nil:
+		if policy.Digest.Use != "" {
+			return nil, fmt.Errorf("%w: digest credentials from secret '%s'", ErrUnsupportedPolicy, policy.Digest.Use)
+		}
+		return &digestTransport{username: policy.Digest.Username, password: policy.Digest.Password, next: next}, nil
+	case policy.OAuth2 != nil:
+		if policy.OAuth2.Use != "" {
+			return nil, fmt.Errorf("%w: oauth2 properties from secret '%s'", ErrUnsupportedPolicy, policy.OAuth2.Use)
+		}
+		if provider == nil {
+			provider = NewTokenProvider()
+		}
+		oauth2 := policy.OAuth2
+		return &oauth2Transport{
+			token:      func(ctx context.Context) (string, error) { return provider.Token(ctx, oauth2) },
+			invalidate: func() { provider.Invalidate(oauth2) },
+			next:       next,
+		}, nil
+	case policy.OIDC != nil:
+		if policy.OIDC.Use != "" {
+			return nil, fmt.Errorf("%w: oidc properties from secret '%s'", ErrUnsupportedPolicy, policy.OIDC.Use)
+		}
+		if provider == nil {
+			provider = NewTokenProvider()
+		}
+		oidc := policy.OIDC
+		return &oauth2Transport{
+			token:      func(ctx context.Context) (string, error) { return provider.OIDCToken(ctx, oidc) },
+			invalidate: func() { provider.InvalidateOIDC(oidc) },
+			next:       next,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: no authentication scheme defined", ErrUnsupportedPolicy)
+}
+
+// Authorization returns the `Authorization` header value for the policy, for protocols such as gRPC that carry the
+// credentials as request metadata. Challenge based schemes like digest cannot be applied this way.
+func Authorization(ctx context.Context, policy *model.AuthenticationPolicy, provider *TokenProvider) (string, error) {
+	if provider == nil {
+		provider = NewTokenProvider()
+	}
+	switch {
+	case policy == nil:
+		return "", nil
+	case policy.Basic != nil && policy.Basic.Use == "":
+		credentials := base64.StdEncoding.EncodeToString([]byte(policy.Basic.Username + ":" + policy.Basic.Password))
+		return "Basic " + credentials, nil
+	case policy.Bearer != nil && policy.Bearer.Use == "":
+		return "Bearer " + policy.Bearer.Token, nil
+	case policy.OAuth2 != nil && policy.OAuth2.Use == "":
+		token, err := provider.Token(ctx, policy.OAuth2)
+		if err != nil {
+			return "", err
+		}
+		return "Bearer " + token, nil
+	case policy.OIDC != nil && policy.OIDC.Use == "":
+		token, err := provider.OIDCToken(ctx, policy.OIDC)
+		if err != nil {
+			return "", err
+		}
+		return "Bearer " + token, nil
+	}
+	return "", fmt.Errorf("%w: the policy cannot be sent as an authorization header", ErrUnsupportedPolicy)
+}
+
+// NewClient returns a copy of client whose transport applies the given policy.
+func NewClient(client *http.Client, policy *model.AuthenticationPolicy, provider *TokenProvider) (*http.Client, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	transport, err := NewTransport(policy, client.Transport, provider)
+	if err != nil {
+		return nil, err
+	}
+	authenticated := *client
+	authenticated.Transport = transport
+	return &authenticated, nil
+}
+
+// authorizes reports whether the credentials of the policy apply to the request. They are not sent along a redirect
+// to another host, whose `Authorization` header net/http would otherwise strip.
+func authorizes(req *http.Request) bool {
+	original := req
+	for original.Response != nil && original.Response.Request != nil {
+		original = original.Response.Request
+	}
+	return strings.EqualFold(original.URL.Host, req.URL.Host)
+}
+
+type basicTransport struct {
+	username string
+	password string
+	next     http.RoundTripper
+}
+
+func (t *basicTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !authorizes(req) {
+		return t.next.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	req.SetBasicAuth(t.username, t.password)
+	return t.next.RoundTrip(req)
+}
+
+type bearerTransport struct {
+	token string
+	next  http.RoundTripper
+}
+
+func (t *bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !authorizes(req) {
+		return t.next.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolvePolicy(t *testing.T) {
+	basic := model.NewBasicAuth("admin", "secret")
+	workflow := &model.Workflow{Use: &model.Use{Authentications: map[string]*model.AuthenticationPolicy{"petStore": basic}}}
+
+	policy, err := ResolvePolicy(&model.ReferenceableAuthenticationPolicy{AuthenticationPolicy: basic}, nil)
+	assert.NoError(t, err)
+	assert.Same(t, basic, policy)
+
+	ref := "petStore"
+	policy, err = ResolvePolicy(&model.ReferenceableAuthenticationPolicy{Use: &ref}, workflow)
+	assert.NoError(t, err)
+	assert.Same(t, basic, policy)
+
+	missing := "unknown"
+	_, err = ResolvePolicy(&model.ReferenceableAuthenticationPolicy{Use: &missing}, workflow)
+	assert.True(t, errors.Is(err, ErrPolicyNotFound))
+
+	policy, err = ResolvePolicy(nil, workflow)
+	assert.NoError(t, err)
+	assert.Nil(t, policy)
+}
+
+func TestNewClient_BasicAndBearer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+	}))
+	defer server.Close()
+
+	testCases := map[string]struct {
+		policy   *model.AuthenticationPolicy
+		expected string
+	}{
+		"basic":  {policy: model.NewBasicAuth("admin", "secret"), expected: "Basic YWRtaW46c2VjcmV0"},
+		"bearer": {policy: &model.AuthenticationPolicy{Bearer: &model.BearerAuthenticationPolicy{Token: "abc"}}, expected: "Bearer abc"},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, err := NewClient(server.Client(), tc.policy, nil)
+			assert.NoError(t, err)
+			resp, err := client.Get(server.URL)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+			body := make([]byte, 64)
+			n, _ := resp.Body.Read(body)
+			assert.Equal(t, tc.expected, string(body[:n]))
+		})
+	}
+}
+
+func TestNewClient_Redirects(t *testing.T) {
+	echo := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+	})
+	other := httptest.NewServer(echo)
+	defer other.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/same":
+			http.Redirect(w, r, "/echo", http.StatusFound)
+		case "/other":
+			http.Redirect(w, r, other.URL, http.StatusFound)
+		default:
+			echo(w, r)
+		}
+	}))
+	defer server.Close()
+
+	policies := map[string]*model.AuthenticationPolicy{
+		"basic":  model.NewBasicAuth("admin", "secret"),
+		"bearer": {Bearer: &model.BearerAuthenticationPolicy{Token: "abc"}},
+	}
+	for name, policy := range policies {
+		t.Run(name, func(t *testing.T) {
+			client, err := NewClient(server.Client(), policy, nil)
+			assert.NoError(t, err)
+			get := func(path string) string {
+				resp, err := client.Get(server.URL + path)
+				assert.NoError(t, err)
+				defer resp.Body.Close()
+				body, err := io.ReadAll(resp.Body)
+				assert.NoError(t, err)
+				return string(body)
+			}
+			assert.NotEmpty(t, get("/same"))
+			assert.Empty(t, get("/other"))
+		})
+	}
+}
+
+func TestAuthorization(t *testing.T) {
+	value, err := Authorization(context.Background(), model.NewBasicAuth("admin", "secret"), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "Basic YWRtaW46c2VjcmV0", value)
+
+	value, err = Authorization(context.Background(), &model.AuthenticationPolicy{Bearer: &model.BearerAuthenticationPolicy{Token: "abc"}}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer abc", value)
+
+	_, err = Authorization(context.Background(), &model.AuthenticationPolicy{Digest: &model.DigestAuthenticationPolicy{Username: "admin"}}, nil)
+	assert.True(t, errors.Is(err, ErrUnsupportedPolicy))
+}
@@ -0,0 +1,280 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"bytes"
+	"crypto/md5" // #nosec G501 -- MD5 is mandated by RFC 7616 for legacy digest servers
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	digestScheme   = "digest"
+	digestQopAuth  = "auth"
+	digestQopInt   = "auth-int"
+	digestSessTail = "-sess"
+)
+
+// digestAlgorithms lists the supported algorithms from the strongest to the weakest, see RFC 7616 section 3.2.
+var digestAlgorithms = []struct {
+	name string
+	hash func() hash.Hash
+}{
+	{name: "SHA-512-256", hash: sha512.New512_256},
+	{name: "SHA-256", hash: sha256.New},
+	{name: "MD5", hash: md5.New},
+}
+
+// digestTransport runs the RFC 7616 challenge/response handshake: the request is first sent without credentials and,
+// when the server answers with a Digest challenge, replayed with the computed Authorization header.
+type digestTransport struct {
+	username string
+	password string
+	next     http.RoundTripper
+}
+
+func (t *digestTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !authorizes(req) {
+		return t.next.RoundTrip(req)
+	}
+	req, body, err := replayableRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	challenge, ok := selectDigestChallenge(resp.Header.Values("WWW-Authenticate"))
+	if !ok {
+		return resp, nil
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	cnonce, err := newCnonce()
+	if err != nil {
+		return nil, err
+	}
+	authorization, err := challenge.authorize(t.username, t.password, req.Method, req.URL.RequestURI(), body, cnonce)
+	if err != nil {
+		return nil, err
+	}
+
+	retry := req.Clone(req.Context())
+	if body != nil {
+		retry.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	retry.Header.Set("Authorization", authorization)
+	return t.next.RoundTrip(retry)
+}
+
+// replayableRequest buffers the request body so it can be sent twice.
+func replayableRequest(req *http.Request) (*http.Request, []byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to buffer request body for digest authentication: %w", err)
+	}
+	clone := req.Clone(req.Context())
+	clone.Body = io.NopCloser(bytes.NewReader(body))
+	return clone, body, nil
+}
+
+type digestChallenge struct {
+	realm     string
+	nonce     string
+	opaque    string
+	algorithm string
+	qop       []string
+	userhash  bool
+	hash      func() hash.Hash
+}
+
+// selectDigestChallenge picks the Digest challenge with the strongest algorithm this transport supports.
+func selectDigestChallenge(headers []string) (*digestChallenge, bool) {
+	var selected *digestChallenge
+	rank := len(digestAlgorithms)
+	for _, header := range headers {
+		scheme, params, found := strings.Cut(strings.TrimSpace(header), " ")
+		if !found || !strings.EqualFold(scheme, digestScheme) {
+			continue
+		}
+		challenge := parseDigestChallenge(params)
+		if challenge.nonce == "" {
+			continue
+		}
+		base := strings.TrimSuffix(strings.ToUpper(challenge.algorithm), strings.ToUpper(digestSessTail))
+		if base == "" {
+			base = "MD5"
+		}
+		for i, algorithm := range digestAlgorithms {
+			if algorithm.name == base && i < rank {
+				challenge.hash = algorithm.hash
+				selected, rank = challenge, i
+			}
+		}
+	}
+	return selected, selected != nil
+}
+
+func parseDigestChallenge(params string) *digestChallenge {
+	challenge := &digestChallenge{}
+	for key, value := range parseAuthParams(params) {
+		switch strings.ToLower(key) {
+		case "realm":
+			challenge.realm = value
+		case "nonce":
+			challenge.nonce = value
+		case "opaque":
+			challenge.opaque = value
+		case "algorithm":
+			challenge.algorithm = value
+		case "qop":
+			for _, qop := range strings.Split(value, ",") {
+				challenge.qop = append(challenge.qop, strings.TrimSpace(qop))
+			}
+		case "userhash":
+			challenge.userhash = strings.EqualFold(value, "true")
+		}
+	}
+	return challenge
+}
+
+// parseAuthParams parses a comma separated list of auth-params, honoring quoted strings and escapes.
+func parseAuthParams(s string) map[string]string {
+	params := map[string]string{}
+	for len(s) > 0 {
+		s = strings.TrimLeft(s, " \t,")
+		key, rest, found := strings.Cut(s, "=")
+		if !found {
+			break
+		}
+		key = strings.TrimSpace(key)
+		rest = strings.TrimLeft(rest, " \t")
+
+		var value strings.Builder
+		if strings.HasPrefix(rest, `"`) {
+			i := 1
+			for ; i < len(rest) && rest[i] != '"'; i++ {
+				if rest[i] == '\\' && i+1 < len(rest) {
+					i++
+				}
+				value.WriteByte(rest[i])
+			}
+			s = rest[min(i+1, len(rest)):]
+		} else {
+			end := strings.IndexByte(rest, ',')
+			if end < 0 {
+				end = len(rest)
+			}
+			value.WriteString(strings.TrimSpace(rest[:end]))
+			s = rest[end:]
+		}
+		params[key] = value.String()
+	}
+	return params
+}
+
+func (c *digestChallenge) h(data string) string {
+	hasher := c.hash()
+	hasher.Write([]byte(data))
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+
+func (c *digestChallenge) selectQop() string {
+	qop := ""
+	for _, offered := range c.qop {
+		switch offered {
+		case digestQopAuth:
+			return digestQopAuth
+		case digestQopInt:
+			qop = digestQopInt
+		}
+	}
+	return qop
+}
+
+// authorize computes the Authorization header value for the given request, see RFC 7616 section 3.4.
+func (c *digestChallenge) authorize(username, password, method, uri string, body []byte, cnonce string) (string, error) {
+	if len(c.qop) > 0 && c.selectQop() == "" {
+		return "", errors.New("digest challenge offers no supported qop")
+	}
+	const nc = "00000001"
+	qop := c.selectQop()
+
+	ha1 := c.h(username + ":" + c.realm + ":" + password)
+	if strings.HasSuffix(strings.ToLower(c.algorithm), digestSessTail) {
+		ha1 = c.h(ha1 + ":" + c.nonce + ":" + cnonce)
+	}
+	a2 := method + ":" + uri
+	if qop == digestQopInt {
+		a2 += ":" + c.h(string(body))
+	}
+	ha2 := c.h(a2)
+
+	var response string
+	if qop == "" {
+		response = c.h(ha1 + ":" + c.nonce + ":" + ha2)
+	} else {
+		response = c.h(ha1 + ":" + c.nonce + ":" + nc + ":" + cnonce + ":" + qop + ":" + ha2)
+	}
+
+	if c.userhash {
+		username = c.h(username + ":" + c.realm)
+	}
+	fields := []string{
+		fmt.Sprintf("username=%q", username),
+		fmt.Sprintf("realm=%q", c.realm),
+		fmt.Sprintf("uri=%q", uri),
+		fmt.Sprintf("nonce=%q", c.nonce),
+		fmt.Sprintf("response=%q", response),
+	}
+	if c.algorithm != "" {
+		fields = append(fields, "algorithm="+c.algorithm)
+	}
+	if qop != "" {
+		fields = append(fields, "qop="+qop, "nc="+nc, fmt.Sprintf("cnonce=%q", cnonce))
+	}
+	if c.opaque != "" {
+		fields = append(fields, fmt.Sprintf("opaque=%q", c.opaque))
+	}
+	if c.userhash {
+		fields = append(fields, "userhash=true")
+	}
+	return "Digest " + strings.Join(fields, ", "), nil
+}
+
+func newCnonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate digest cnonce: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"crypto/md5" // #nosec G501
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// RFC 7616 section 3.9.1 example.
+const (
+	rfcUsername = "Mufasa"
+	rfcPassword = "Circle of Life"
+	rfcRealm    = "http-auth@example.org"
+	rfcNonce    = "7ypf/xlj9XXwfDPEoM4URrv/xwf94BcCAzFZH4GiTo0v"
+	rfcCnonce   = "f2/wE4q74E6zIJEtWaHKaf5wv/H5QzzpXusqGemxURZJ"
+	rfcOpaque   = "FQhe/qaU925kfnzjCev0ciny7QMkPqMAFRtzCUYo5tdS"
+)
+
+func TestDigestChallenge_RFC7616Example(t *testing.T) {
+	header := `Digest realm="http-auth@example.org", qop="auth, auth-int", algorithm=%s, nonce="` + rfcNonce + `", opaque="` + rfcOpaque + `"`
+
+	testCases := []struct {
+		algorithm string
+		response  string
+	}{
+		{algorithm: "SHA-256", response: "753927fa0e85d155564e2e272a28d1802ca10daf4496794697cf8db5856cb6c1"},
+		{algorithm: "MD5", response: "8ca523f5e9506fed4657c9700eebdbec"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.algorithm, func(t *testing.T) {
+			challenge, ok := selectDigestChallenge([]string{strings.Replace(header, "%s", tc.algorithm, 1)})
+			assert.True(t, ok)
+			authorization, err := challenge.authorize(rfcUsername, rfcPassword, http.MethodGet, "/dir/index.html", nil, rfcCnonce)
+			assert.NoError(t, err)
+			params := parseAuthParams(strings.TrimPrefix(authorization, "Digest "))
+			assert.Equal(t, tc.response, params["response"])
+			assert.Equal(t, "auth", params["qop"])
+			assert.Equal(t, rfcOpaque, params["opaque"])
+		})
+	}
+}
+
+func TestSelectDigestChallenge_PrefersStrongestAlgorithm(t *testing.T) {
+	challenge, ok := selectDigestChallenge([]string{
+		`Basic realm="x"`,
+		`Digest realm="r", nonce="n", algorithm=MD5`,
+		`Digest realm="r", nonce="n", algorithm=SHA-256`,
+	})
+	assert.True(t, ok)
+	assert.Equal(t, "SHA-256", challenge.algorithm)
+
+	_, ok = selectDigestChallenge([]string{`Basic realm="x"`})
+	assert.False(t, ok)
+}
+
+// newDigestServer verifies qop=auth digest credentials the same way RFC 7616 servers do.
+func newDigestServer(t *testing.T, algorithm string, newHash func() hash.Hash) *httptest.Server {
+	h := func(data string) string {
+		hasher := newHash()
+		hasher.Write([]byte(data))
+		return hex.EncodeToString(hasher.Sum(nil))
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization := r.Header.Get("Authorization")
+		if !strings.HasPrefix(authorization, "Digest ") {
+			w.Header().Add("WWW-Authenticate", `Digest realm="`+rfcRealm+`", qop="auth", algorithm=`+algorithm+`, nonce="`+rfcNonce+`", opaque="`+rfcOpaque+`"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		params := parseAuthParams(strings.TrimPrefix(authorization, "Digest "))
+		ha1 := h(params["username"] + ":" + rfcRealm + ":" + rfcPassword)
+		ha2 := h(r.Method + ":" + params["uri"])
+		expected := h(ha1 + ":" + rfcNonce + ":" + params["nc"] + ":" + params["cnonce"] + ":" + params["qop"] + ":" + ha2)
+		if params["response"] != expected || params["opaque"] != rfcOpaque || params["uri"] != r.URL.RequestURI() {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(append([]byte("welcome "), body...))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestDigestTransport_Handshake(t *testing.T) {
+	for algorithm, newHash := range map[string]func() hash.Hash{"SHA-256": sha256.New, "MD5": md5.New} {
+		t.Run(algorithm, func(t *testing.T) {
+			server := newDigestServer(t, algorithm, newHash)
+
+			client, err := NewClient(server.Client(), &model.AuthenticationPolicy{
+				Digest: &model.DigestAuthenticationPolicy{Username: rfcUsername, Password: rfcPassword},
+			}, nil)
+			assert.NoError(t, err)
+
+			resp, err := client.Post(server.URL+"/dir/index.html?q=1", "text/plain", strings.NewReader("Simba"))
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, "welcome Simba", string(body))
+		})
+	}
+
+	t.Run("wrong password is rejected", func(t *testing.T) {
+		server := newDigestServer(t, "SHA-256", sha256.New)
+		client, err := NewClient(server.Client(), &model.AuthenticationPolicy{
+			Digest: &model.DigestAuthenticationPolicy{Username: rfcUsername, Password: "Hakuna Matata"},
+		}, nil)
+		assert.NoError(t, err)
+		resp, err := client.Get(server.URL)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+}
@@ -0,0 +1,488 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+)
+
+const (
+	clientAssertionTypeJWT = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+	oidcDiscoveryPath      = "/.well-known/openid-configuration"
+	defaultRefreshWindow   = 30 * time.Second
+	clientAssertionTTL     = 5 * time.Minute
+)
+
+// ErrAuthenticationFailed is returned when the authorization server refuses to issue a token.
+var ErrAuthenticationFailed = errors.New("authentication failed")
+
+// TokenProviderOption configures a TokenProvider.
+type TokenProviderOption func(*TokenProvider)
+
+// WithTokenClient sets the client used to talk to authorization servers. Defaults to http.DefaultClient.
+func WithTokenClient(client *http.Client) TokenProviderOption {
+	return func(p *TokenProvider) {
+		p.client = client
+	}
+}
+
+// WithClock overrides the time source used to decide whether a cached token expired.
+func WithClock(now func() time.Time) TokenProviderOption {
+	return func(p *TokenProvider) {
+		p.now = now
+	}
+}
+
+// WithRefreshWindow sets how long before its expiration a cached token is renewed. Defaults to 30 seconds.
+func WithRefreshWindow(window time.Duration) TokenProviderOption {
+	return func(p *TokenProvider) {
+		p.refreshWindow = window
+	}
+}
+
+// TokenProvider acquires OAuth2 access tokens from the policy's token endpoint, or from the endpoint found through
+// OpenID Connect discovery. Tokens are cached per policy, scopes and audiences and renewed before they expire,
+// using the refresh token when the server issued one.
+type TokenProvider struct {
+	client        *http.Client
+	now           func() time.Time
+	refreshWindow time.Duration
+
+	mu        sync.Mutex
+	tokens    map[string]*cachedToken
+	discovery map[string]*oidcConfiguration
+}
+
+type cachedToken struct {
+	accessToken  string
+	refreshToken string
+	expiresAt    time.Time
+}
+
+type tokenResponse struct {
+	AccessToken      string      `json:"access_token"`
+	TokenType        string      `json:"token_type"`
+	ExpiresIn        json.Number `json:"expires_in"`
+	RefreshToken     string      `json:"refresh_token"`
+	Error            string      `json:"error"`
+	ErrorDescription string      `json:"error_description"`
+}
+
+type oidcConfiguration struct {
+	Issuer        string `json:"issuer"`
+	TokenEndpoint string `json:"token_endpoint"`
+}
+
+// NewTokenProvider creates a TokenProvider with an empty cache.
+func NewTokenProvider(opts ...TokenProviderOption) *TokenProvider {
+	p := &TokenProvider{
+		client:        http.DefaultClient,
+		now:           time.Now,
+		refreshWindow: defaultRefreshWindow,
+		tokens:        map[string]*cachedToken{},
+		discovery:     map[string]*oidcConfiguration{},
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Token returns a valid access token for the OAuth2 policy.
+func (p *TokenProvider) Token(ctx context.Context, policy *model.OAuth2AuthenticationPolicy) (string, error) {
+	if policy == nil || policy.Properties == nil {
+		return "", fmt.Errorf("%w: oauth2 policy has no properties", ErrUnsupportedPolicy)
+	}
+	tokenURL, err := oauth2TokenURL(policy)
+	if err != nil {
+		return "", err
+	}
+	return p.token(ctx, policy.Properties, tokenURL)
+}
+
+// OIDCToken returns a valid access token for the OpenID Connect policy, discovering the token endpoint from the authority.
+func (p *TokenProvider) OIDCToken(ctx context.Context, policy *model.OpenIdConnectAuthenticationPolicy) (string, error) {
+	if policy == nil || policy.Properties == nil {
+		return "", fmt.Errorf("%w: oidc policy has no properties", ErrUnsupportedPolicy)
+	}
+	configuration, err := p.discover(ctx, policy.Properties)
+	if err != nil {
+		return "", err
+	}
+	return p.token(ctx, policy.Properties, configuration.TokenEndpoint)
+}
+
+// Invalidate evicts the cached token for the OAuth2 policy, e.g. after the resource server rejected it.
+func (p *TokenProvider) Invalidate(policy *model.OAuth2AuthenticationPolicy) {
+	if policy == nil || policy.Properties == nil {
+		return
+	}
+	if tokenURL, err := oauth2TokenURL(policy); err == nil {
+		p.evict(tokenCacheKey(policy.Properties, tokenURL))
+	}
+}
+
+// InvalidateOIDC evicts the cached token for the OpenID Connect policy.
+func (p *TokenProvider) InvalidateOIDC(policy *model.OpenIdConnectAuthenticationPolicy) {
+	if policy == nil || policy.Properties == nil || policy.Properties.Authority == nil {
+		return
+	}
+	p.mu.Lock()
+	configuration, ok := p.discovery[policy.Properties.Authority.String()]
+	p.mu.Unlock()
+	if ok {
+		p.evict(tokenCacheKey(policy.Properties, configuration.TokenEndpoint))
+	}
+}
+
+func (p *TokenProvider) evict(key string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.tokens, key)
+}
+
+func (p *TokenProvider) token(ctx context.Context, props *model.OAuth2AuthenticationProperties, tokenURL string) (string, error) {
+	key := tokenCacheKey(props, tokenURL)
+
+	p.mu.Lock()
+	cached := p.tokens[key]
+	p.mu.Unlock()
+
+	if cached != nil && p.isFresh(cached) {
+		return cached.accessToken, nil
+	}
+
+	var (
+		issued *cachedToken
+		err    error
+	)
+	if cached != nil && cached.refreshToken != "" {
+		issued, err = p.requestToken(ctx, props, tokenURL, model.RefreshTokenGrant, cached.refreshToken)
+	}
+	if issued == nil {
+		if issued, err = p.requestToken(ctx, props, tokenURL, props.Grant, ""); err != nil {
+			return "", err
+		}
+	}
+	if issued.refreshToken == "" && cached != nil {
+		issued.refreshToken = cached.refreshToken
+	}
+
+	p.mu.Lock()
+	p.tokens[key] = issued
+	p.mu.Unlock()
+	return issued.accessToken, nil
+}
+
+func (p *TokenProvider) isFresh(token *cachedToken) bool {
+	return token.expiresAt.IsZero() || p.now().Add(p.refreshWindow).Before(token.expiresAt)
+}
+
+// requestToken calls the token endpoint, see RFC 6749 section 4 and RFC 8693 for the token exchange grant.
+func (p *TokenProvider) requestToken(ctx context.Context, props *model.OAuth2AuthenticationProperties, tokenURL string, grant model.OAuth2AuthenticationDataGrant, refreshToken string) (*cachedToken, error) {
+	params := url.Values{}
+	params.Set("grant_type", string(grant))
+	if len(props.Scopes) > 0 {
+		params.Set("scope", strings.Join(props.Scopes, " "))
+	}
+	for _, audience := range props.Audiences {
+		params.Add("audience", audience)
+	}
+
+	switch grant {
+	case model.ClientCredentialsGrant:
+	case model.PasswordGrant:
+		params.Set("username", props.Username)
+		params.Set("password", props.Password)
+	case model.RefreshTokenGrant:
+		// a refresh grant configured in the policy starts from the token given as subject
+		if refreshToken == "" && props.Subject != nil {
+			refreshToken = props.Subject.Token
+		}
+		if refreshToken == "" {
+			return nil, fmt.Errorf("%w: refresh_token grant requires a refresh token", ErrUnsupportedPolicy)
+		}
+		params.Set("refresh_token", refreshToken)
+	case model.TokenExchangeGrant:
+		if props.Subject == nil || props.Subject.Token == "" {
+			return nil, fmt.Errorf("%w: token exchange grant requires a subject token", ErrUnsupportedPolicy)
+		}
+		params.Set("subject_token", props.Subject.Token)
+		params.Set("subject_token_type", props.Subject.Type)
+		if props.Actor != nil && props.Actor.Token != "" {
+			params.Set("actor_token", props.Actor.Token)
+			params.Set("actor_token_type", props.Actor.Type)
+		}
+	default:
+		return nil, fmt.Errorf("%w: oauth2 grant '%s'", ErrUnsupportedPolicy, grant)
+	}
+
+	header := http.Header{}
+	if err := authenticateClient(props.Client, tokenURL, params, header, p.now()); err != nil {
+		return nil, err
+	}
+
+	req, err := newTokenRequest(ctx, props.Request, tokenURL, params, header)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("token request to %s failed: %w", tokenURL, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token response from %s: %w", tokenURL, err)
+	}
+	var token tokenResponse
+	_ = json.Unmarshal(body, &token)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("%w: token endpoint %s responded with status %s: %s %s", ErrAuthenticationFailed, tokenURL, resp.Status, token.Error, token.ErrorDescription)
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("%w: token endpoint %s returned no access token", ErrAuthenticationFailed, tokenURL)
+	}
+
+	issued := &cachedToken{accessToken: token.AccessToken, refreshToken: token.RefreshToken}
+	if expiresIn, err := token.ExpiresIn.Int64(); err == nil && expiresIn > 0 {
+		issued.expiresAt = p.now().Add(time.Duration(expiresIn) * time.Second)
+	}
+	return issued, nil
+}
+
+func newTokenRequest(ctx context.Context, request *model.OAuth2TokenRequest, tokenURL string, params url.Values, header http.Header) (*http.Request, error) {
+	var (
+		body        []byte
+		contentType = string(model.EncodingTypeFormUrlEncoded)
+	)
+	if request != nil && request.Encoding == model.EncodingTypeApplicationJson {
+		payload := map[string]interface{}{}
+		for k, v := range params {
+			if len(v) == 1 {
+				payload[k] = v[0]
+			} else {
+				payload[k] = v
+			}
+		}
+		var err error
+		if body, err = json.Marshal(payload); err != nil {
+			return nil, err
+		}
+		contentType = string(model.EncodingTypeApplicationJson)
+	} else {
+		body = []byte(params.Encode())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header = header
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", "application/json")
+	return req, nil
+}
+
+// authenticateClient adds the client credentials to the token request, see RFC 6749 section 2.3 and RFC 7523.
+// Clients default to client_secret_post as the specification mandates.
+func authenticateClient(client *model.OAuth2AutenthicationDataClient, tokenURL string, params url.Values, header http.Header, now time.Time) error {
+	if client == nil {
+		return nil
+	}
+	switch client.Authentication {
+	case model.OAuthClientAuthClientSecretBasic:
+		header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString(
+			[]byte(url.QueryEscape(client.ID)+":"+url.QueryEscape(client.Secret))))
+	case model.OAuthClientAuthClientSecretJWT:
+		assertion := client.Assertion
+		if assertion == "" {
+			var err error
+			if assertion, err = newClientSecretJWT(client.ID, client.Secret, tokenURL, now); err != nil {
+				return err
+			}
+		}
+		params.Set("client_assertion_type", clientAssertionTypeJWT)
+		params.Set("client_assertion", assertion)
+	case model.OAuthClientAuthPrivateKeyJWT:
+		if client.Assertion == "" {
+			return fmt.Errorf("%w: private_key_jwt requires a signed client assertion", ErrUnsupportedPolicy)
+		}
+		params.Set("client_assertion_type", clientAssertionTypeJWT)
+		params.Set("client_assertion", client.Assertion)
+	case model.OAuthClientAuthNone:
+		params.Set("client_id", client.ID)
+	default:
+		params.Set("client_id", client.ID)
+		if client.Secret != "" {
+			params.Set("client_secret", client.Secret)
+		}
+	}
+	return nil
+}
+
+// newClientSecretJWT builds a HS256 client assertion signed with the client secret, issued at now.
+func newClientSecretJWT(clientID, secret, audience string, now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "HS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]interface{}{
+		"iss": clientID,
+		"sub": clientID,
+		"aud": audience,
+		"jti": uuid.NewString(),
+		"iat": now.Unix(),
+		"exp": now.Add(clientAssertionTTL).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signingInput))
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// discover fetches the OpenID Connect provider configuration of the policy authority, see OpenID Connect Discovery 1.0.
+func (p *TokenProvider) discover(ctx context.Context, props *model.OAuth2AuthenticationProperties) (*oidcConfiguration, error) {
+	if props.Authority == nil {
+		return nil, fmt.Errorf("%w: oidc policy requires an authority", ErrUnsupportedPolicy)
+	}
+	authority := props.Authority.String()
+
+	p.mu.Lock()
+	configuration, ok := p.discovery[authority]
+	p.mu.Unlock()
+	if ok {
+		return configuration, nil
+	}
+
+	discoveryURL := strings.TrimSuffix(authority, "/") + oidcDiscoveryPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discoveryURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("oidc discovery at %s failed: %w", discoveryURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: oidc discovery at %s responded with status %s", ErrAuthenticationFailed, discoveryURL, resp.Status)
+	}
+
+	configuration = &oidcConfiguration{}
+	if err := json.NewDecoder(resp.Body).Decode(configuration); err != nil {
+		return nil, fmt.Errorf("invalid oidc discovery document at %s: %w", discoveryURL, err)
+	}
+	if configuration.TokenEndpoint == "" {
+		return nil, fmt.Errorf("%w: oidc discovery document at %s has no token_endpoint", ErrAuthenticationFailed, discoveryURL)
+	}
+	if len(props.Issuers) > 0 && !contains(props.Issuers, configuration.Issuer) {
+		return nil, fmt.Errorf("%w: issuer '%s' is not one of the trusted issuers %v", ErrAuthenticationFailed, configuration.Issuer, props.Issuers)
+	}
+
+	p.mu.Lock()
+	p.discovery[authority] = configuration
+	p.mu.Unlock()
+	return configuration, nil
+}
+
+// oauth2TokenURL resolves the token endpoint against the policy authority.
+func oauth2TokenURL(policy *model.OAuth2AuthenticationPolicy) (string, error) {
+	tokenPath := model.OAuth2DefaultTokenURI
+	if policy.Endpoints != nil && policy.Endpoints.Token != "" {
+		tokenPath = policy.Endpoints.Token
+	}
+	if model.LiteralUriPattern.MatchString(tokenPath) {
+		return tokenPath, nil
+	}
+	if policy.Properties.Authority == nil {
+		return "", fmt.Errorf("%w: oauth2 policy requires an authority", ErrUnsupportedPolicy)
+	}
+	return strings.TrimSuffix(policy.Properties.Authority.String(), "/") + "/" + strings.TrimPrefix(tokenPath, "/"), nil
+}
+
+func tokenCacheKey(props *model.OAuth2AuthenticationProperties, tokenURL string) string {
+	scopes := append([]string(nil), props.Scopes...)
+	sort.Strings(scopes)
+	audiences := append([]string(nil), props.Audiences...)
+	sort.Strings(audiences)
+
+	clientID, subject := "", ""
+	if props.Client != nil {
+		clientID = props.Client.ID
+	}
+	if props.Subject != nil {
+		subject = props.Subject.Token
+	}
+	return strings.Join([]string{
+		tokenURL, string(props.Grant), clientID, props.Username, subject,
+		strings.Join(scopes, " "), strings.Join(audiences, " "),
+	}, "|")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// oauth2Transport attaches a bearer token obtained from the TokenProvider and evicts it when the server rejects it.
+type oauth2Transport struct {
+	token      func(ctx context.Context) (string, error)
+	invalidate func()
+	next       http.RoundTripper
+}
+
+func (t *oauth2Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !authorizes(req) {
+		return t.next.RoundTrip(req)
+	}
+	token, err := t.token(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := t.next.RoundTrip(req)
+	if err == nil && resp.StatusCode == http.StatusUnauthorized {
+		t.invalidate()
+	}
+	return resp, err
+}
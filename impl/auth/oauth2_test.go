@@ -0,0 +1,295 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// authorizationServer is a minimal OAuth2/OIDC server recording the token requests it receives.
+type authorizationServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests []url.Values
+	issued   int
+	issuer   string
+}
+
+func newAuthorizationServer(t *testing.T) *authorizationServer {
+	as := &authorizationServer{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		issuer := as.issuer
+		if issuer == "" {
+			issuer = as.URL
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"issuer": issuer, "token_endpoint": as.URL + "/oidc/token"})
+	})
+	token := func(w http.ResponseWriter, r *http.Request) {
+		params := url.Values{}
+		if r.Header.Get("Content-Type") == "application/json" {
+			var payload map[string]string
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			for k, v := range payload {
+				params.Set(k, v)
+			}
+		} else {
+			assert.NoError(t, r.ParseForm())
+			params = r.PostForm
+		}
+		if id, secret, ok := r.BasicAuth(); ok {
+			params.Set("client_id", id)
+			params.Set("client_secret", secret)
+		}
+
+		as.mu.Lock()
+		as.requests = append(as.requests, params)
+		as.issued++
+		issued := as.issued
+		as.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if params.Get("client_secret") == "wrong" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error": "invalid_client"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token":  fmt.Sprintf("token-%d", issued),
+			"token_type":    "Bearer",
+			"expires_in":    60,
+			"refresh_token": fmt.Sprintf("refresh-%d", issued),
+		})
+	}
+	mux.HandleFunc("POST /oauth2/token", token)
+	mux.HandleFunc("POST /oidc/token", token)
+	mux.HandleFunc("GET /resource", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "Bearer revoked" || !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer token-") {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+	})
+	as.Server = httptest.NewServer(mux)
+	t.Cleanup(as.Close)
+	return as
+}
+
+func (as *authorizationServer) lastRequest() url.Values {
+	as.mu.Lock()
+	defer as.mu.Unlock()
+	return as.requests[len(as.requests)-1]
+}
+
+func newOAuth2Policy(authority string, configure func(props *model.OAuth2AuthenticationProperties)) *model.OAuth2AuthenticationPolicy {
+	props := &model.OAuth2AuthenticationProperties{
+		Authority: &model.LiteralUri{Value: authority},
+		Grant:     model.ClientCredentialsGrant,
+		Client:    &model.OAuth2AutenthicationDataClient{ID: "workflow", Secret: "s3cr3t"},
+		Scopes:    []string{"pets:write", "pets:read"},
+	}
+	if configure != nil {
+		configure(props)
+	}
+	return &model.OAuth2AuthenticationPolicy{Properties: props}
+}
+
+func TestTokenProvider_Token(t *testing.T) {
+	t.Run("client credentials are posted and the token cached until it is about to expire", func(t *testing.T) {
+		as := newAuthorizationServer(t)
+		now := time.Now()
+		provider := NewTokenProvider(WithTokenClient(as.Client()), WithClock(func() time.Time { return now }))
+		policy := newOAuth2Policy(as.URL, nil)
+
+		token, err := provider.Token(context.Background(), policy)
+		assert.NoError(t, err)
+		assert.Equal(t, "token-1", token)
+		request := as.lastRequest()
+		assert.Equal(t, "client_credentials", request.Get("grant_type"))
+		assert.Equal(t, "workflow", request.Get("client_id"))
+		assert.Equal(t, "s3cr3t", request.Get("client_secret"))
+		assert.Equal(t, "pets:write pets:read", request.Get("scope"))
+
+		// scopes in another order share the cached token
+		token, err = provider.Token(context.Background(), newOAuth2Policy(as.URL, func(props *model.OAuth2AuthenticationProperties) {
+			props.Scopes = []string{"pets:read", "pets:write"}
+		}))
+		assert.NoError(t, err)
+		assert.Equal(t, "token-1", token)
+		assert.Equal(t, 1, as.issued)
+
+		now = now.Add(45 * time.Second)
+		token, err = provider.Token(context.Background(), policy)
+		assert.NoError(t, err)
+		assert.Equal(t, "token-2", token)
+		assert.Equal(t, "refresh_token", as.lastRequest().Get("grant_type"))
+		assert.Equal(t, "refresh-1", as.lastRequest().Get("refresh_token"))
+	})
+
+	t.Run("password grant with client_secret_basic and JSON encoding", func(t *testing.T) {
+		as := newAuthorizationServer(t)
+		provider := NewTokenProvider(WithTokenClient(as.Client()))
+		policy := newOAuth2Policy(as.URL, func(props *model.OAuth2AuthenticationProperties) {
+			props.Grant = model.PasswordGrant
+			props.Username = "alice"
+			props.Password = "wonderland"
+			props.Client.Authentication = model.OAuthClientAuthClientSecretBasic
+			props.Request = &model.OAuth2TokenRequest{Encoding: model.EncodingTypeApplicationJson}
+		})
+
+		token, err := provider.Token(context.Background(), policy)
+		assert.NoError(t, err)
+		assert.Equal(t, "token-1", token)
+		request := as.lastRequest()
+		assert.Equal(t, "password", request.Get("grant_type"))
+		assert.Equal(t, "alice", request.Get("username"))
+		assert.Equal(t, "wonderland", request.Get("password"))
+		assert.Equal(t, "workflow", request.Get("client_id"))
+		assert.Equal(t, "s3cr3t", request.Get("client_secret"))
+	})
+
+	t.Run("token exchange and client_secret_jwt", func(t *testing.T) {
+		as := newAuthorizationServer(t)
+		issuedAt := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+		provider := NewTokenProvider(WithTokenClient(as.Client()), WithClock(func() time.Time { return issuedAt }))
+		policy := newOAuth2Policy(as.URL, func(props *model.OAuth2AuthenticationProperties) {
+			props.Grant = model.TokenExchangeGrant
+			props.Subject = &model.OAuth2Token{Token: "subject", Type: "urn:ietf:params:oauth:token-type:access_token"}
+			props.Client.Authentication = model.OAuthClientAuthClientSecretJWT
+		})
+
+		_, err := provider.Token(context.Background(), policy)
+		assert.NoError(t, err)
+		request := as.lastRequest()
+		assert.Equal(t, string(model.TokenExchangeGrant), request.Get("grant_type"))
+		assert.Equal(t, "subject", request.Get("subject_token"))
+		assert.Equal(t, clientAssertionTypeJWT, request.Get("client_assertion_type"))
+		assertion := strings.Split(request.Get("client_assertion"), ".")
+		assert.Len(t, assertion, 3)
+		assert.Empty(t, request.Get("client_secret"))
+
+		payload, err := base64.RawURLEncoding.DecodeString(assertion[1])
+		assert.NoError(t, err)
+		var claims map[string]interface{}
+		assert.NoError(t, json.Unmarshal(payload, &claims))
+		assert.Equal(t, float64(issuedAt.Unix()), claims["iat"])
+		assert.Equal(t, float64(issuedAt.Add(clientAssertionTTL).Unix()), claims["exp"])
+	})
+
+	t.Run("rejected client is an authentication failure", func(t *testing.T) {
+		as := newAuthorizationServer(t)
+		provider := NewTokenProvider(WithTokenClient(as.Client()))
+		_, err := provider.Token(context.Background(), newOAuth2Policy(as.URL, func(props *model.OAuth2AuthenticationProperties) {
+			props.Client.Secret = "wrong"
+		}))
+		assert.True(t, errors.Is(err, ErrAuthenticationFailed))
+		assert.Contains(t, err.Error(), "invalid_client")
+	})
+}
+
+func TestTokenProvider_OIDCToken(t *testing.T) {
+	t.Run("token endpoint is discovered", func(t *testing.T) {
+		as := newAuthorizationServer(t)
+		provider := NewTokenProvider(WithTokenClient(as.Client()))
+		policy := &model.OpenIdConnectAuthenticationPolicy{Properties: newOAuth2Policy(as.URL, func(props *model.OAuth2AuthenticationProperties) {
+			props.Issuers = []string{as.URL}
+		}).Properties}
+
+		token, err := provider.OIDCToken(context.Background(), policy)
+		assert.NoError(t, err)
+		assert.Equal(t, "token-1", token)
+	})
+
+	t.Run("untrusted issuer is refused", func(t *testing.T) {
+		as := newAuthorizationServer(t)
+		as.issuer = "https://evil.example.com"
+		provider := NewTokenProvider(WithTokenClient(as.Client()))
+		policy := &model.OpenIdConnectAuthenticationPolicy{Properties: newOAuth2Policy(as.URL, func(props *model.OAuth2AuthenticationProperties) {
+			props.Issuers = []string{as.URL}
+		}).Properties}
+
+		_, err := provider.OIDCToken(context.Background(), policy)
+		assert.True(t, errors.Is(err, ErrAuthenticationFailed))
+		assert.Equal(t, 0, as.issued)
+	})
+}
+
+func TestOAuth2Transport(t *testing.T) {
+	as := newAuthorizationServer(t)
+	provider := NewTokenProvider(WithTokenClient(as.Client()))
+	policy := newOAuth2Policy(as.URL, nil)
+	client, err := NewClient(as.Client(), &model.AuthenticationPolicy{OAuth2: policy}, provider)
+	assert.NoError(t, err)
+
+	resp, err := client.Get(as.URL + "/resource")
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// a token rejected by the resource server is evicted and a new one requested on the next call
+	provider.mu.Lock()
+	for _, token := range provider.tokens {
+		token.accessToken = "revoked"
+	}
+	provider.mu.Unlock()
+	resp, err = client.Get(as.URL + "/resource")
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp, err = client.Get(as.URL + "/resource")
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, as.issued)
+}
+
+func TestOAuth2Transport_Redirects(t *testing.T) {
+	as := newAuthorizationServer(t)
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+	}))
+	defer other.Close()
+	redirecting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, other.URL, http.StatusFound)
+	}))
+	defer redirecting.Close()
+
+	provider := NewTokenProvider(WithTokenClient(as.Client()))
+	client, err := NewClient(redirecting.Client(), &model.AuthenticationPolicy{OAuth2: newOAuth2Policy(as.URL, nil)}, provider)
+	assert.NoError(t, err)
+	resp, err := client.Get(redirecting.URL)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Empty(t, string(body))
+	assert.Equal(t, 1, as.issued)
+}
@@ -0,0 +1,158 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/serverlessworkflow/sdk-go/v3/parser"
+)
+
+// maxCatalogFunctionSize bounds the definition of a function loaded from an HTTP catalog.
+const maxCatalogFunctionSize = 1 << 20
+
+// ErrFunctionNotFound is returned when a catalog has no definition for a function version.
+var ErrFunctionNotFound = errors.New("function not found in catalog")
+
+// ErrCatalogUnavailable is returned when a remote catalog cannot be reached.
+var ErrCatalogUnavailable = errors.New("catalog unavailable")
+
+// CatalogResolverOption configures the CatalogResolver.
+type CatalogResolverOption func(*CatalogResolver)
+
+// WithCatalogHTTPClient sets the client used to load functions from HTTP catalogs. Defaults to http.DefaultClient.
+func WithCatalogHTTPClient(client *http.Client) CatalogResolverOption {
+	return func(r *CatalogResolver) {
+		r.client = client
+	}
+}
+
+// WithDefaultCatalog sets the endpoint of the `default` catalog.
+func WithDefaultCatalog(endpoint string) CatalogResolverOption {
+	return func(r *CatalogResolver) {
+		r.defaultCatalog = endpoint
+	}
+}
+
+// CatalogResolver loads the functions shared in catalogs. A catalog is a directory, local (`file://`) or served over
+// HTTP, laid out as the specification mandates: every function version is defined in
+// `functions/{name}/{version}/function.yaml`. Loaded functions are cached for the lifetime of the resolver.
+type CatalogResolver struct {
+	client         *http.Client
+	defaultCatalog string
+	mu             sync.Mutex
+	functions      map[string]model.Task
+}
+
+func NewCatalogResolver(opts ...CatalogResolverOption) *CatalogResolver {
+	resolver := &CatalogResolver{client: http.DefaultClient, functions: map[string]model.Task{}}
+	for _, opt := range opts {
+		opt(resolver)
+	}
+	return resolver
+}
+
+// Resolve returns the definition of the function version in the catalog found at the endpoint, an empty endpoint
+// standing for the default catalog.
+func (r *CatalogResolver) Resolve(ctx context.Context, endpoint, name, version string) (model.Task, error) {
+	if endpoint == "" {
+		if r.defaultCatalog == "" {
+			return nil, errors.New("no default catalog configured")
+		}
+		endpoint = r.defaultCatalog
+	}
+	if !isCatalogSegment(name) || !isCatalogSegment(version) {
+		return nil, fmt.Errorf("%w: invalid function reference %s:%s", ErrFunctionNotFound, name, version)
+	}
+	location, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid catalog endpoint %s: %w", endpoint, err)
+	}
+	location = location.JoinPath("functions", name, version, "function.yaml")
+
+	key := location.String()
+	r.mu.Lock()
+	function, ok := r.functions[key]
+	r.mu.Unlock()
+	if ok {
+		return function, nil
+	}
+
+	var source []byte
+	switch location.Scheme {
+	case "file":
+		source, err = os.ReadFile(filepath.FromSlash(path.Clean(location.Path)))
+		if errors.Is(err, os.ErrNotExist) {
+			err = fmt.Errorf("%w: %s:%s", ErrFunctionNotFound, name, version)
+		}
+	case "http", "https":
+		source, err = r.fetch(ctx, location.String(), name, version)
+	default:
+		err = fmt.Errorf("unsupported catalog endpoint %s, must be a file:// or http(s):// URI", endpoint)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if function, err = parser.TaskFromYAMLSource(source); err != nil {
+		return nil, fmt.Errorf("invalid definition of function %s:%s: %w", name, version, err)
+	}
+	r.mu.Lock()
+	r.functions[key] = function
+	r.mu.Unlock()
+	return function, nil
+}
+
+func (r *CatalogResolver) fetch(ctx context.Context, location, name, version string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCatalogUnavailable, err)
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("%w: %s:%s", ErrFunctionNotFound, name, version)
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return nil, fmt.Errorf("%w: failed to load function %s:%s from %s: %s", ErrCatalogUnavailable, name, version, location, resp.Status)
+	}
+	source, err := io.ReadAll(io.LimitReader(resp.Body, maxCatalogFunctionSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCatalogUnavailable, err)
+	}
+	if len(source) > maxCatalogFunctionSize {
+		return nil, fmt.Errorf("definition of function %s:%s at %s exceeds %d bytes", name, version, location, maxCatalogFunctionSize)
+	}
+	return source, nil
+}
+
+// isCatalogSegment reports whether the function name or version stays a single path segment of the catalog.
+func isCatalogSegment(segment string) bool {
+	return segment != "" && !strings.HasPrefix(segment, ".") && !strings.ContainsAny(segment, "/\\")
+}
@@ -0,0 +1,157 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/serverlessworkflow/sdk-go/v3/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func fileURI(path string) string {
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
+
+func fileCatalogURI(t *testing.T) string {
+	dir, err := filepath.Abs("./testdata/catalog")
+	assert.NoError(t, err)
+	return fileURI(dir)
+}
+
+func loadCatalogWorkflow(t *testing.T, endpoint string) *model.Workflow {
+	workflow, err := parser.FromFile("./testdata/call_catalog_function.yaml")
+	assert.NoError(t, err)
+	workflow.Use.Catalogs["shared"].Endpoint = model.NewEndpoint(endpoint)
+	return workflow
+}
+
+func TestCatalogFunctions(t *testing.T) {
+	expected := map[string]interface{}{"greeting": "Hello Hello Alice!"}
+
+	t.Run("file catalog", func(t *testing.T) {
+		runner, err := NewDefaultRunner(loadCatalogWorkflow(t, fileCatalogURI(t)))
+		assert.NoError(t, err)
+		output, err := runner.Run(map[string]interface{}{"owner": "Alice"})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, output)
+	})
+
+	t.Run("HTTP catalog is loaded once", func(t *testing.T) {
+		var requests atomic.Int32
+		files := http.FileServer(http.Dir("./testdata/catalog"))
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			files.ServeHTTP(w, r)
+		}))
+		defer server.Close()
+
+		resolver := NewCatalogResolver(WithCatalogHTTPClient(server.Client()))
+		runner, err := NewDefaultRunner(loadCatalogWorkflow(t, server.URL), WithCatalogResolver(resolver))
+		assert.NoError(t, err)
+		output, err := runner.Run(map[string]interface{}{"owner": "Alice"})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, output)
+		assert.Equal(t, int32(1), requests.Load())
+	})
+
+	t.Run("oversized HTTP definitions are rejected", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("set:\n  padding: '" + strings.Repeat("x", maxCatalogFunctionSize) + "'\n"))
+		}))
+		defer server.Close()
+
+		resolver := NewCatalogResolver(WithCatalogHTTPClient(server.Client()))
+		_, err := resolver.Resolve(t.Context(), server.URL, "greet", "1.0.0")
+		assert.ErrorContains(t, err, "exceeds")
+	})
+
+	t.Run("default catalog", func(t *testing.T) {
+		task := &model.CallFunction{Call: "greet:1.0.0@default", With: map[string]interface{}{"name": "Bob"}}
+		runner, err := NewCallFunctionTaskRunner("greet", task, &model.Workflow{})
+		assert.NoError(t, err)
+
+		_, err = runner.Run(nil, newTaskSupport(withCatalogResolver(NewCatalogResolver())))
+		assert.True(t, model.IsErrConfiguration(err))
+
+		resolver := NewCatalogResolver(WithDefaultCatalog(fileCatalogURI(t)))
+		function, err := resolver.Resolve(t.Context(), "", "greet", "1.0.0")
+		assert.NoError(t, err)
+		assert.IsType(t, &model.SetTask{}, function)
+	})
+
+	t.Run("functions calling themselves endlessly are stopped", func(t *testing.T) {
+		task := &model.CallFunction{Call: "forever:1.0.0@default"}
+		runner, err := NewCallFunctionTaskRunner("forever", task, &model.Workflow{})
+		assert.NoError(t, err)
+
+		resolver := NewCatalogResolver(WithDefaultCatalog(fileCatalogURI(t)))
+		_, err = runner.Run(nil, newTaskSupport(withCatalogResolver(resolver)))
+		assert.True(t, model.IsErrConfiguration(err))
+		assert.ErrorContains(t, err, "function calls nest deeper than 64")
+	})
+
+	t.Run("functions may only use the secrets declared by the workflow", func(t *testing.T) {
+		workflow := &model.Workflow{
+			Use: &model.Use{},
+			Do:  &model.TaskList{{Key: "authorize", Task: &model.CallFunction{Call: "authorize:1.0.0@default"}}},
+		}
+		resolver := NewCatalogResolver(WithDefaultCatalog(fileCatalogURI(t)))
+		secrets := NewMemorySecretProvider(map[string]interface{}{"apiToken": "t0k3n"})
+
+		runner, err := NewDefaultRunner(workflow, WithCatalogResolver(resolver), WithSecretProvider(secrets))
+		assert.NoError(t, err)
+		_, err = runner.Run(nil)
+		assert.True(t, model.IsErrConfiguration(err))
+		assert.ErrorContains(t, err, ErrSecretNotDeclared.Error())
+
+		workflow.Use.Secrets = []string{"apiToken"}
+		runner, err = NewDefaultRunner(workflow, WithCatalogResolver(resolver), WithSecretProvider(secrets))
+		assert.NoError(t, err)
+		output, err := runner.Run(nil)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"authorization": "Bearer t0k3n"}, output)
+	})
+
+	t.Run("unknown version is a configuration error", func(t *testing.T) {
+		task := &model.CallFunction{Call: "greet:2.0.0@shared"}
+		workflow := loadCatalogWorkflow(t, fileCatalogURI(t))
+		runner, err := NewCallFunctionTaskRunner("greet", task, workflow)
+		assert.NoError(t, err)
+		_, err = runner.Run(nil, newTaskSupport(withWorkflow(workflow), withCatalogResolver(NewCatalogResolver())))
+		assert.True(t, model.IsErrConfiguration(err))
+		assert.ErrorContains(t, err, ErrFunctionNotFound.Error())
+	})
+
+	t.Run("function references cannot escape the catalog", func(t *testing.T) {
+		resolver := NewCatalogResolver(WithDefaultCatalog(fileCatalogURI(t) + "/functions/greet"))
+		_, err := resolver.Resolve(t.Context(), "", "..", "1.0.0")
+		assert.ErrorIs(t, err, ErrFunctionNotFound)
+		_, err = resolver.Resolve(t.Context(), "", "greet", "../../greet/1.0.0")
+		assert.ErrorIs(t, err, ErrFunctionNotFound)
+	})
+
+	t.Run("undeclared catalog fails validation", func(t *testing.T) {
+		_, err := NewCallFunctionTaskRunner("greet", &model.CallFunction{Call: "greet:1.0.0@private"}, &model.Workflow{})
+		assert.True(t, model.IsErrValidation(err))
+	})
+}
@@ -0,0 +1,214 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/serverlessworkflow/sdk-go/v3/impl/ctx"
+	"github.com/serverlessworkflow/sdk-go/v3/impl/utils"
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+)
+
+// executionStack tracks the frames the instance runs in, to checkpoint its position, and the frames it resumes from.
+// Resuming is structural: every list of tasks, `for` loop or `try` task entered while resuming takes the next frame.
+type executionStack struct {
+	mu         sync.Mutex
+	definition json.RawMessage
+	frames     []ExecutionFrame
+	resume     []ExecutionFrame
+}
+
+func newExecutionStack(workflow *model.Workflow) (*executionStack, error) {
+	definition, err := json.Marshal(workflow)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal workflow definition: %w", err)
+	}
+	return &executionStack{definition: definition}, nil
+}
+
+func (s *executionStack) push() *ExecutionFrame {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.resume) == 0 {
+		s.frames = append(s.frames, ExecutionFrame{})
+		return nil
+	}
+	frame := s.resume[0]
+	s.resume = s.resume[1:]
+	s.frames = append(s.frames, frame)
+	return &frame
+}
+
+func (s *executionStack) set(index int, input interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.frames) > 0 {
+		s.frames[len(s.frames)-1] = ExecutionFrame{Index: index, Input: input}
+	}
+}
+
+func (s *executionStack) pop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.frames) > 0 {
+		s.frames = s.frames[:len(s.frames)-1]
+	}
+}
+
+func (s *executionStack) position() []ExecutionFrame {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	position := make([]ExecutionFrame, len(s.frames))
+	for i, frame := range s.frames {
+		position[i] = ExecutionFrame{Index: frame.Index, Input: utils.DeepCloneValue(frame.Input)}
+	}
+	return position
+}
+
+var _ executionTracker = &workflowRunnerImpl{}
+
+// executionTracker is implemented by the TaskSupport of the default runner, which tracks the position of the instance
+// to checkpoint and resume it.
+type executionTracker interface {
+	// pushFrame enters a list of tasks, a `for` loop or a `try` task, returning the frame to resume it from when the
+	// instance is being resumed, nil otherwise. popFrame leaves it.
+	pushFrame() *ExecutionFrame
+	// setFrame records the position of the instance in the innermost frame
+	setFrame(index int, input interface{})
+	popFrame()
+	// checkpoint persists the instance along with its position, when a StateStore is configured
+	checkpoint() error
+	// untracked returns a TaskSupport sharing this workflow context whose tasks are not tracked, e.g. the ones of
+	// extensions, which run again along with the task they extend once resumed
+	untracked() TaskSupport
+}
+
+// trackerOf returns the tracker of the position of the instance, one tracking nothing when the TaskSupport does not.
+func trackerOf(taskSupport TaskSupport) executionTracker {
+	if tracker, ok := taskSupport.(executionTracker); ok {
+		return tracker
+	}
+	return noTracker{taskSupport}
+}
+
+// noTracker is the tracker of TaskSupport implementations that do not checkpoint instances.
+type noTracker struct {
+	taskSupport TaskSupport
+}
+
+func (noTracker) pushFrame() *ExecutionFrame {
+	return nil
+}
+
+func (noTracker) setFrame(int, interface{}) {}
+
+func (noTracker) popFrame() {}
+
+func (noTracker) checkpoint() error {
+	return nil
+}
+
+func (t noTracker) untracked() TaskSupport {
+	return t.taskSupport
+}
+
+func (wr *workflowRunnerImpl) pushFrame() *ExecutionFrame {
+	if wr.position == nil {
+		return nil
+	}
+	return wr.position.push()
+}
+
+func (wr *workflowRunnerImpl) setFrame(index int, input interface{}) {
+	if wr.position != nil {
+		wr.position.set(index, input)
+	}
+}
+
+func (wr *workflowRunnerImpl) popFrame() {
+	if wr.position != nil {
+		wr.position.pop()
+	}
+}
+
+func (wr *workflowRunnerImpl) untracked() TaskSupport {
+	clone := *wr
+	clone.position = nil
+	return &clone
+}
+
+func (wr *workflowRunnerImpl) checkpoint() error {
+	if wr.position == nil {
+		return nil
+	}
+	if err := wr.saveState(); err != nil {
+		return model.NewErrRuntime(err, wr.taskReference())
+	}
+	return nil
+}
+
+func (wr *workflowRunnerImpl) saveState() error {
+	state := &InstanceState{
+		InstanceID: wr.RunnerCtx.GetInstanceID(),
+		Definition: wr.position.definition,
+		Context:    wr.RunnerCtx.Snapshot(),
+		Position:   wr.position.position(),
+	}
+	// the checkpoint of a cancelled instance records it
+	if err := wr.StateStore.Save(context.WithoutCancel(wr.Context), state); err != nil {
+		return fmt.Errorf("failed to checkpoint workflow instance %s: %w", state.InstanceID, err)
+	}
+	return nil
+}
+
+// saveFinalState records the outcome of the instance. The outcome is already decided: failing to save it leaves the
+// previous checkpoint in place, the instance being resumed from there if asked to.
+func (wr *workflowRunnerImpl) saveFinalState() {
+	if wr.position != nil {
+		_ = wr.saveState()
+	}
+}
+
+// ResumeInstance restores the instance persisted in the store and continues it in the background, from the task
+// following its last checkpoint. Tasks that were running when the instance stopped run again, `fork` tasks from their
+// start. The options configure the runner like the one that started the instance; the secrets are resolved again.
+func ResumeInstance(parent context.Context, store StateStore, instanceID string, opts ...RunnerOption) (WorkflowHandle, error) {
+	state, err := store.Load(parent, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	if state.Context == nil {
+		return nil, fmt.Errorf("invalid state of workflow instance %s: missing context", instanceID)
+	}
+	if isFinalStatus(state.Context.Status()) {
+		return nil, fmt.Errorf("%w: %s is %s", ErrInstanceNotRunning, instanceID, state.Context.Status())
+	}
+	workflow := &model.Workflow{}
+	if err = json.Unmarshal(state.Definition, workflow); err != nil {
+		return nil, fmt.Errorf("invalid definition of workflow instance %s: %w", instanceID, err)
+	}
+
+	runner, err := newWorkflowRunnerWithContext(parent, workflow, ctx.RestoreWorkflowContext(state.Context), append(opts, WithStateStore(store))...)
+	if err != nil {
+		return nil, err
+	}
+	runner.position.resume = state.Position
+	runner.control.start()
+	return runner.runInBackground(runner.resume), nil
+}
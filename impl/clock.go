@@ -0,0 +1,63 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+)
+
+// Clock is the time source used by tasks that wait, e.g. retry delays. Tests can replace it to avoid sleeping.
+type Clock interface {
+	Now() time.Time
+	// After waits for the duration to elapse and then sends the current time on the returned channel.
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// sleep blocks until the clock reports the duration elapsed or the context is done, returning the context error.
+func sleep(ctx context.Context, clock Clock, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-clock.After(d):
+		return nil
+	}
+}
+
+// newContextErr reports a task interrupted by its context: an exceeded deadline is a timeout error, anything else
+// a cancellation reported as a runtime error.
+func newContextErr(err error, instance string) *model.Error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return model.NewErrTimeout(fmt.Errorf("'%s' timed out: %w", instance, err), instance)
+	}
+	return model.NewErrRuntime(fmt.Errorf("'%s' cancelled: %w", instance, err), instance)
+}
@@ -0,0 +1,150 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ContainerRuntime runs the containers of `run: container` tasks, e.g. on Docker or Kubernetes.
+type ContainerRuntime interface {
+	// Start pulls the image as the pull policy requires, then creates and starts the container. It returns the
+	// identifier of the container.
+	Start(ctx context.Context, spec ContainerSpec) (string, error)
+	// Wait blocks until the container exits.
+	Wait(ctx context.Context, id string) (*ContainerResult, error)
+	// Remove deletes the container, stopping it first if it still runs.
+	Remove(ctx context.Context, id string) error
+}
+
+// ContainerSpec is a container definition with its runtime expressions evaluated.
+type ContainerSpec struct {
+	Image       string
+	Name        string
+	Command     string
+	Arguments   []string
+	Environment map[string]string
+	Ports       map[string]interface{}
+	Volumes     map[string]interface{}
+	Stdin       string
+	PullPolicy  string
+}
+
+// ContainerResult is the outcome of an exited container.
+type ContainerResult struct {
+	Code   int
+	Stdout string
+	Stderr string
+}
+
+// ErrContainerNotFound is returned by runtimes asked for a container that does not exist, or was removed.
+var ErrContainerNotFound = errors.New("container not found")
+
+var _ ContainerRuntime = &FakeContainerRuntime{}
+
+// FakeContainerRuntime runs containers in process, handing their specification to a function that plays the
+// container. It lets workflows running containers be run, and tested, without a container engine.
+type FakeContainerRuntime struct {
+	mu         sync.Mutex
+	run        func(ctx context.Context, spec ContainerSpec) (*ContainerResult, error)
+	containers map[string]*fakeContainer
+	started    []ContainerSpec
+	sequence   int
+}
+
+type fakeContainer struct {
+	spec   ContainerSpec
+	done   chan struct{}
+	result *ContainerResult
+	err    error
+}
+
+func NewFakeContainerRuntime(run func(ctx context.Context, spec ContainerSpec) (*ContainerResult, error)) *FakeContainerRuntime {
+	return &FakeContainerRuntime{run: run, containers: map[string]*fakeContainer{}}
+}
+
+func (f *FakeContainerRuntime) Start(ctx context.Context, spec ContainerSpec) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sequence++
+	id := fmt.Sprintf("fake-%d", f.sequence)
+	container := &fakeContainer{spec: spec, done: make(chan struct{})}
+	f.containers[id] = container
+	f.started = append(f.started, spec)
+
+	// the container runs on its own, like a real one would
+	go func() {
+		defer close(container.done)
+		container.result, container.err = f.run(context.Background(), spec)
+	}()
+	return id, nil
+}
+
+func (f *FakeContainerRuntime) Wait(ctx context.Context, id string) (*ContainerResult, error) {
+	container, err := f.container(id)
+	if err != nil {
+		return nil, err
+	}
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-container.done:
+		return container.result, container.err
+	}
+}
+
+func (f *FakeContainerRuntime) Remove(_ context.Context, id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.containers[id]; !ok {
+		return ErrContainerNotFound
+	}
+	delete(f.containers, id)
+	return nil
+}
+
+// Started returns the specification of every container started so far.
+func (f *FakeContainerRuntime) Started() []ContainerSpec {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]ContainerSpec(nil), f.started...)
+}
+
+// Containers returns the identifiers of the containers that were not removed.
+func (f *FakeContainerRuntime) Containers() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ids := make([]string, 0, len(f.containers))
+	for id := range f.containers {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func (f *FakeContainerRuntime) container(id string) (*fakeContainer, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	container, ok := f.containers[id]
+	if !ok {
+		return nil, ErrContainerNotFound
+	}
+	return container, nil
+}
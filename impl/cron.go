@@ -0,0 +1,205 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSchedule is a parsed cron expression, every field holding the set of its allowed values as a bit mask.
+type cronSchedule struct {
+	second, minute, hour, dom, month, dow uint64
+	// domRestricted and dowRestricted tell whether the day fields were set: when both are, a day matching
+	// either of them fires, as in the standard cron.
+	domRestricted, dowRestricted bool
+}
+
+type cronField struct {
+	name     string
+	min, max uint
+	names    map[string]uint
+}
+
+var (
+	cronSecond = cronField{name: "second", min: 0, max: 59}
+	cronMinute = cronField{name: "minute", min: 0, max: 59}
+	cronHour   = cronField{name: "hour", min: 0, max: 23}
+	cronDom    = cronField{name: "day of month", min: 1, max: 31}
+	cronMonth  = cronField{name: "month", min: 1, max: 12, names: map[string]uint{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// cronDow accepts 7 as Sunday, folded onto 0 once parsed
+	cronDow = cronField{name: "day of week", min: 0, max: 7, names: map[string]uint{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// parseCron parses the standard 5 fields syntax (minute, hour, day of month, month and day of week), optionally
+// preceded by a seconds field, or one of the @yearly, @monthly, @weekly, @daily and @hourly macros.
+func parseCron(expression string) (*cronSchedule, error) {
+	expression = strings.TrimSpace(expression)
+	if macro, ok := cronMacros[strings.ToLower(expression)]; ok {
+		expression = macro
+	}
+	fields := strings.Fields(expression)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("cron expression '%s' must have 5 or 6 fields, found %d", expression, len(fields))
+	}
+
+	schedule := &cronSchedule{}
+	var err error
+	targets := []struct {
+		mask  *uint64
+		field cronField
+	}{
+		{&schedule.second, cronSecond},
+		{&schedule.minute, cronMinute},
+		{&schedule.hour, cronHour},
+		{&schedule.dom, cronDom},
+		{&schedule.month, cronMonth},
+		{&schedule.dow, cronDow},
+	}
+	for i, target := range targets {
+		if *target.mask, err = target.field.parse(fields[i]); err != nil {
+			return nil, fmt.Errorf("invalid cron expression '%s': %w", expression, err)
+		}
+	}
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow = schedule.dow&^(1<<7) | 1
+	}
+	schedule.domRestricted = !isCronWildcard(fields[3])
+	schedule.dowRestricted = !isCronWildcard(fields[5])
+	return schedule, nil
+}
+
+func isCronWildcard(field string) bool {
+	return field == "*" || field == "?"
+}
+
+// parse reads a comma separated list of values, ranges and steps, e.g. "1,15-20,*/10".
+func (f cronField) parse(field string) (uint64, error) {
+	var mask uint64
+	for _, part := range strings.Split(field, ",") {
+		expression, step := part, uint(1)
+		if i := strings.Index(part, "/"); i >= 0 {
+			value, err := strconv.ParseUint(part[i+1:], 10, 8)
+			if err != nil || value == 0 {
+				return 0, fmt.Errorf("invalid step in %s '%s'", f.name, part)
+			}
+			expression, step = part[:i], uint(value)
+		}
+
+		from, to := f.min, f.max
+		switch {
+		case isCronWildcard(expression):
+		case strings.Contains(expression, "-"):
+			bounds := strings.SplitN(expression, "-", 2)
+			var err error
+			if from, err = f.value(bounds[0]); err != nil {
+				return 0, err
+			}
+			if to, err = f.value(bounds[1]); err != nil {
+				return 0, err
+			}
+			if from > to {
+				return 0, fmt.Errorf("invalid range in %s '%s'", f.name, part)
+			}
+		default:
+			var err error
+			if from, err = f.value(expression); err != nil {
+				return 0, err
+			}
+			// a single value with a step, e.g. 5/15, starts there and runs to the end of the field
+			if step == 1 {
+				to = from
+			}
+		}
+		for value := from; value <= to; value += step {
+			mask |= 1 << value
+		}
+	}
+	return mask, nil
+}
+
+func (f cronField) value(text string) (uint, error) {
+	if value, ok := f.names[strings.ToLower(text)]; ok {
+		return value, nil
+	}
+	value, err := strconv.ParseUint(text, 10, 8)
+	if err != nil || uint(value) < f.min || uint(value) > f.max {
+		return 0, fmt.Errorf("invalid %s '%s', must be between %d and %d", f.name, text, f.min, f.max)
+	}
+	return uint(value), nil
+}
+
+// cronSearchLimit bounds the search for the next activation of expressions that never fire, e.g. on February 30.
+const cronSearchLimit = 5
+
+// next returns the first activation strictly after t, in the location of t, or the zero time if there is none.
+func (s *cronSchedule) next(t time.Time) time.Time {
+	t = t.Truncate(time.Second).Add(time.Second)
+	limit := t.AddDate(cronSearchLimit, 0, 0)
+	loc := t.Location()
+	for t.Before(limit) {
+		year, month, day := t.Date()
+		switch {
+		case s.month&(1<<uint(month)) == 0:
+			t = time.Date(year, month+1, 1, 0, 0, 0, 0, loc)
+		case !s.matchDay(t):
+			t = time.Date(year, month, day+1, 0, 0, 0, 0, loc)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			next := time.Date(year, month, day, t.Hour()+1, 0, 0, 0, loc)
+			// leaving a repeated hour when the clocks go back would otherwise go back in time
+			if !next.After(t) {
+				next = t.Truncate(time.Minute).Add(time.Minute)
+			}
+			t = next
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Truncate(time.Minute).Add(time.Minute)
+		case s.second&(1<<uint(t.Second())) == 0:
+			t = t.Add(time.Second)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (s *cronSchedule) matchDay(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domRestricted && s.dowRestricted {
+		return dom || dow
+	}
+	return dom && dow
+}
@@ -43,6 +43,7 @@ const (
 	varsWorkflow = "$workflow"
 	varsRuntime  = "$runtime"
 	varsTask     = "$task"
+	varsSecrets  = "$secrets"
 
 	// TODO: script during the release to update this value programmatically
 	runtimeVersion = "v3.1.0"
@@ -50,8 +51,20 @@ const (
 )
 
 type WorkflowContext interface {
+	// GetInstanceID returns the identifier of the workflow instance, also known as `$workflow.id`.
+	GetInstanceID() string
+	// SetParentInstanceID correlates a nested instance, e.g. run by a `run: workflow` task, with its parent.
+	SetParentInstanceID(id string)
+	// GetParentInstanceID returns the identifier of the parent instance, empty for top-level instances.
+	GetParentInstanceID() string
+	// SetSecrets sets the values of the secrets declared by the workflow, exposed to expressions as `$secrets`.
+	SetSecrets(secrets map[string]interface{})
+	// GetSecrets returns the values of the secrets declared by the workflow.
+	GetSecrets() map[string]interface{}
 	SetStartedAt(t time.Time)
 	SetStatus(status StatusPhase)
+	// GetStatus returns the current status of the workflow instance.
+	GetStatus() StatusPhase
 	SetRawInput(input interface{})
 	SetInstanceCtx(value interface{})
 	GetInstanceCtx() interface{}
@@ -62,6 +75,8 @@ type WorkflowContext interface {
 	GetOutputAsMap() map[string]interface{}
 	GetVars() map[string]interface{}
 	SetTaskStatus(task string, status StatusPhase)
+	// GetTaskStatus returns the current status of the task, empty when the task has not been reached yet.
+	GetTaskStatus(task string) StatusPhase
 	SetTaskRawInput(input interface{})
 	SetTaskRawOutput(output interface{})
 	SetTaskDef(task model.Task) error
@@ -74,6 +89,8 @@ type WorkflowContext interface {
 	AddLocalExprVars(vars map[string]interface{})
 	RemoveLocalExprVars(keys ...string)
 	Clone() WorkflowContext
+	// Snapshot returns the serializable state of the context, secrets excluded.
+	Snapshot() *Snapshot
 }
 
 // workflowContext holds the necessary data for the workflow execution within the instance.
@@ -85,6 +102,8 @@ type workflowContext struct {
 	workflowDescriptor map[string]interface{} // $workflow representation in the context
 	taskDescriptor     map[string]interface{} // $task representation in the context
 	localExprVars      map[string]interface{} // Local expression variables defined in a given task or private context. E.g. a For task $item.
+	parentInstanceID   string                 // The instance running this one as a sub-workflow, if any.
+	secrets            map[string]interface{} // $secrets, the values of the declared secrets. Never copied elsewhere.
 	StatusPhase        []StatusPhaseLog
 	TasksStatusPhase   map[string][]StatusPhaseLog
 }
@@ -148,11 +167,47 @@ func (ctx *workflowContext) Clone() WorkflowContext {
 		workflowDescriptor: newWorkflowDesc,
 		taskDescriptor:     newTaskDesc,
 		localExprVars:      newLocalExprVars,
+		parentInstanceID:   ctx.parentInstanceID,
+		secrets:            ctx.secrets,
 		StatusPhase:        newStatusPhase,
 		TasksStatusPhase:   newTasksStatusPhase,
 	}
 }
 
+func (ctx *workflowContext) GetInstanceID() string {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	if wf, ok := ctx.workflowDescriptor[varsWorkflow].(map[string]interface{}); ok {
+		id, _ := wf["id"].(string)
+		return id
+	}
+	return ""
+}
+
+func (ctx *workflowContext) SetParentInstanceID(id string) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	ctx.parentInstanceID = id
+}
+
+func (ctx *workflowContext) GetParentInstanceID() string {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	return ctx.parentInstanceID
+}
+
+func (ctx *workflowContext) SetSecrets(secrets map[string]interface{}) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	ctx.secrets = secrets
+}
+
+func (ctx *workflowContext) GetSecrets() map[string]interface{} {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	return utils.DeepClone(ctx.secrets)
+}
+
 func (ctx *workflowContext) SetStartedAt(t time.Time) {
 	ctx.mu.Lock()
 	defer ctx.mu.Unlock()
@@ -224,6 +279,7 @@ func (ctx *workflowContext) GetVars() map[string]interface{} {
 	vars[varsContext] = ctx.GetInstanceCtx()
 	vars[varsTask] = ctx.taskDescriptor[varsTask]
 	vars[varsWorkflow] = ctx.workflowDescriptor[varsWorkflow]
+	vars[varsSecrets] = ctx.GetSecrets()
 	vars[varsRuntime] = map[string]interface{}{
 		"name":    runtimeName,
 		"version": runtimeVersion,
@@ -243,6 +299,15 @@ func (ctx *workflowContext) SetStatus(status StatusPhase) {
 	ctx.StatusPhase = append(ctx.StatusPhase, NewStatusPhaseLog(status))
 }
 
+func (ctx *workflowContext) GetStatus() StatusPhase {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	if len(ctx.StatusPhase) == 0 {
+		return ""
+	}
+	return ctx.StatusPhase[len(ctx.StatusPhase)-1].Status
+}
+
 // SetInstanceCtx safely sets the `$context` value
 func (ctx *workflowContext) SetInstanceCtx(value interface{}) {
 	ctx.mu.Lock()
@@ -332,6 +397,16 @@ func (ctx *workflowContext) SetTaskStatus(task string, status StatusPhase) {
 	ctx.TasksStatusPhase[task] = append(ctx.TasksStatusPhase[task], NewStatusPhaseLog(status))
 }
 
+func (ctx *workflowContext) GetTaskStatus(task string) StatusPhase {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	logs := ctx.TasksStatusPhase[task]
+	if len(logs) == 0 {
+		return ""
+	}
+	return logs[len(logs)-1].Status
+}
+
 func (ctx *workflowContext) SetTaskRawInput(input interface{}) {
 	ctx.mu.Lock()
 	defer ctx.mu.Unlock()
@@ -433,7 +508,8 @@ func (ctx *workflowContext) GetTaskReference() string {
 	if !ok {
 		return ""
 	}
-	return task["reference"].(string)
+	reference, _ := task["reference"].(string)
+	return reference
 }
 
 func (ctx *workflowContext) ClearTaskContext() {
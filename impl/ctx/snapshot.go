@@ -0,0 +1,85 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctx
+
+import "github.com/serverlessworkflow/sdk-go/v3/impl/utils"
+
+// Snapshot is the serializable state of a workflow context, taken to persist the instance. The secrets are left out:
+// they are resolved again once the instance is restored.
+type Snapshot struct {
+	Input              interface{}                 `json:"input,omitempty"`
+	Output             interface{}                 `json:"output,omitempty"`
+	Context            map[string]interface{}      `json:"context,omitempty"`
+	WorkflowDescriptor map[string]interface{}      `json:"workflowDescriptor"`
+	TaskDescriptor     map[string]interface{}      `json:"taskDescriptor,omitempty"`
+	LocalExprVars      map[string]interface{}      `json:"localExprVars,omitempty"`
+	ParentInstanceID   string                      `json:"parentInstanceID,omitempty"`
+	StatusPhase        []StatusPhaseLog            `json:"statusPhase"`
+	TasksStatusPhase   map[string][]StatusPhaseLog `json:"tasksStatusPhase,omitempty"`
+}
+
+// Status returns the status of the instance when the snapshot was taken.
+func (s *Snapshot) Status() StatusPhase {
+	if len(s.StatusPhase) == 0 {
+		return ""
+	}
+	return s.StatusPhase[len(s.StatusPhase)-1].Status
+}
+
+func (ctx *workflowContext) Snapshot() *Snapshot {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+
+	tasksStatusPhase := make(map[string][]StatusPhaseLog, len(ctx.TasksStatusPhase))
+	for taskName, logs := range ctx.TasksStatusPhase {
+		tasksStatusPhase[taskName] = append([]StatusPhaseLog(nil), logs...)
+	}
+	return &Snapshot{
+		Input:              utils.DeepCloneValue(ctx.input),
+		Output:             utils.DeepCloneValue(ctx.output),
+		Context:            utils.DeepClone(ctx.context),
+		WorkflowDescriptor: utils.DeepClone(ctx.workflowDescriptor),
+		TaskDescriptor:     utils.DeepClone(ctx.taskDescriptor),
+		LocalExprVars:      utils.DeepClone(ctx.localExprVars),
+		ParentInstanceID:   ctx.parentInstanceID,
+		StatusPhase:        append([]StatusPhaseLog(nil), ctx.StatusPhase...),
+		TasksStatusPhase:   tasksStatusPhase,
+	}
+}
+
+// RestoreWorkflowContext rebuilds the context of a persisted instance from its snapshot.
+func RestoreWorkflowContext(snapshot *Snapshot) WorkflowContext {
+	restored := &workflowContext{
+		input:              utils.DeepCloneValue(snapshot.Input),
+		output:             utils.DeepCloneValue(snapshot.Output),
+		context:            utils.DeepClone(snapshot.Context),
+		workflowDescriptor: utils.DeepClone(snapshot.WorkflowDescriptor),
+		taskDescriptor:     utils.DeepClone(snapshot.TaskDescriptor),
+		localExprVars:      utils.DeepClone(snapshot.LocalExprVars),
+		parentInstanceID:   snapshot.ParentInstanceID,
+		StatusPhase:        append([]StatusPhaseLog(nil), snapshot.StatusPhase...),
+		TasksStatusPhase:   map[string][]StatusPhaseLog{},
+	}
+	if restored.workflowDescriptor == nil {
+		restored.workflowDescriptor = map[string]interface{}{}
+	}
+	if restored.taskDescriptor == nil {
+		restored.taskDescriptor = map[string]interface{}{}
+	}
+	for taskName, logs := range snapshot.TasksStatusPhase {
+		restored.TasksStatusPhase[taskName] = append([]StatusPhaseLog(nil), logs...)
+	}
+	return restored
+}
@@ -0,0 +1,253 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/serverlessworkflow/sdk-go/v3/impl/events"
+	"github.com/serverlessworkflow/sdk-go/v3/impl/expr"
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+)
+
+// eventConsumer applies an event consumption strategy to a stream of events, one event at a time.
+// It is stateful and must be created for every execution of the task that listens.
+type eventConsumer struct {
+	strategy *model.EventConsumptionStrategy
+	taskName string
+	// filters are the strategy filters, with their runtime expressions evaluated against the task input
+	filters  []*eventFilter
+	matched  []bool
+	consumed []*events.Event
+	// correlation holds the values of the correlation keys set by the first consumed event
+	correlation map[string]interface{}
+	until       *eventConsumer
+}
+
+type eventFilter struct {
+	with      map[string]interface{}
+	correlate map[string]model.Correlation
+}
+
+func newEventConsumer(strategy *model.EventConsumptionStrategy, input interface{}, taskSupport TaskSupport, taskName string) (*eventConsumer, error) {
+	if strategy == nil {
+		return nil, model.NewErrValidation(fmt.Errorf("task %s has no event consumption strategy", taskName), taskName)
+	}
+	consumer := &eventConsumer{strategy: strategy, taskName: taskName, correlation: map[string]interface{}{}}
+
+	definitions := strategy.All
+	switch {
+	case strategy.One != nil:
+		definitions = []*model.EventFilter{strategy.One}
+	case len(strategy.Any) > 0:
+		definitions = strategy.Any
+	}
+	for _, definition := range definitions {
+		filter, err := newEventFilter(definition, input, taskSupport, taskName)
+		if err != nil {
+			return nil, err
+		}
+		consumer.filters = append(consumer.filters, filter)
+	}
+	consumer.matched = make([]bool, len(consumer.filters))
+
+	if strategy.Until != nil && (len(strategy.All) > 0 || strategy.One != nil) {
+		return nil, model.NewErrValidation(fmt.Errorf("task %s: 'until' only applies to the 'any' strategy", taskName), taskName)
+	}
+	if strategy.Until != nil && !strategy.Until.IsDisabled && strategy.Until.Strategy != nil {
+		until, err := newEventConsumer(strategy.Until.Strategy, input, taskSupport, taskName)
+		if err != nil {
+			return nil, err
+		}
+		consumer.until = until
+	}
+	return consumer, nil
+}
+
+func newEventFilter(definition *model.EventFilter, input interface{}, taskSupport TaskSupport, taskName string) (*eventFilter, error) {
+	filter := &eventFilter{with: map[string]interface{}{}, correlate: definition.Correlate}
+	if definition.With == nil {
+		return filter, nil
+	}
+	with, err := expr.TraverseAndEvaluate(eventPropertiesToMap(definition.With), input, taskSupport.GetContext())
+	if err != nil {
+		return nil, model.NewErrExpression(err, taskName)
+	}
+	if filter.with, _ = with.(map[string]interface{}); filter.with == nil {
+		return nil, model.NewErrExpression(fmt.Errorf("event filter must evaluate to an object, got %T", with), taskName)
+	}
+	return filter, nil
+}
+
+// offer hands an event to the consumer, which keeps it if the strategy accepts it. It reports whether the
+// strategy is now satisfied.
+func (c *eventConsumer) offer(event *events.Event, input interface{}, taskSupport TaskSupport) (bool, error) {
+	switch {
+	case len(c.strategy.All) > 0:
+		// every filter consumes a single event, the first one it matches
+		for i, filter := range c.filters {
+			if c.matched[i] {
+				continue
+			}
+			keys, ok, err := c.match(filter, event, input, taskSupport)
+			if err != nil {
+				return false, err
+			}
+			if ok {
+				c.consume(event, keys)
+				c.matched[i] = true
+				break
+			}
+		}
+		for _, matched := range c.matched {
+			if !matched {
+				return false, nil
+			}
+		}
+		return true, nil
+	case c.strategy.One != nil:
+		keys, ok, err := c.match(c.filters[0], event, input, taskSupport)
+		if err != nil || !ok {
+			return false, err
+		}
+		c.consume(event, keys)
+		return true, nil
+	default:
+		return c.offerAny(event, input, taskSupport)
+	}
+}
+
+// offerAny consumes events matching any filter, or any event at all without filters. Without `until`, the first
+// consumed event satisfies the strategy, otherwise consumption goes on until the condition or nested strategy holds.
+// With `until: false`, it never does: events are consumed until the task is cancelled or times out.
+func (c *eventConsumer) offerAny(event *events.Event, input interface{}, taskSupport TaskSupport) (bool, error) {
+	until := c.strategy.Until
+	if c.until != nil {
+		done, err := c.until.offer(event, input, taskSupport)
+		if err != nil || done {
+			return done, err
+		}
+	}
+
+	consumed := len(c.filters) == 0
+	var keys map[string]interface{}
+	for _, filter := range c.filters {
+		var err error
+		if keys, consumed, err = c.match(filter, event, input, taskSupport); err != nil {
+			return false, err
+		}
+		if consumed {
+			break
+		}
+	}
+	if !consumed {
+		return false, nil
+	}
+	c.consume(event, keys)
+
+	switch {
+	case until == nil:
+		return true, nil
+	case until.IsDisabled:
+		return false, nil
+	case until.Condition != nil:
+		done, err := expr.TraverseAndEvaluateBool(model.NormalizeExpr(until.Condition.String()), c.output(), taskSupport.GetContext())
+		if err != nil {
+			return false, model.NewErrExpression(err, c.taskName)
+		}
+		return done, nil
+	default:
+		// the nested strategy, offered the event first, is not satisfied yet
+		return false, nil
+	}
+}
+
+func (c *eventConsumer) consume(event *events.Event, correlationKeys map[string]interface{}) {
+	c.consumed = append(c.consumed, event)
+	for key, value := range correlationKeys {
+		c.correlation[key] = value
+	}
+}
+
+// match checks the event attributes against the filter, then its correlation keys. It returns the correlation
+// values extracted from the event.
+func (c *eventConsumer) match(filter *eventFilter, event *events.Event, input interface{}, taskSupport TaskSupport) (map[string]interface{}, bool, error) {
+	attributes := event.Attributes()
+	for name, expected := range filter.with {
+		if !matchEventValue(expected, attributes[name]) {
+			return nil, false, nil
+		}
+	}
+
+	keys := make(map[string]interface{}, len(filter.correlate))
+	for key, correlation := range filter.correlate {
+		value, err := expr.TraverseAndEvaluate(model.NormalizeExpr(correlation.From), attributes, taskSupport.GetContext())
+		if err != nil {
+			return nil, false, model.NewErrExpression(fmt.Errorf("failed to evaluate correlation '%s': %w", key, err), c.taskName)
+		}
+		expected, known := c.correlation[key]
+		if correlation.Expect != "" {
+			known = true
+			if expected, err = expr.TraverseAndEvaluate(correlation.Expect, input, taskSupport.GetContext()); err != nil {
+				return nil, false, model.NewErrExpression(fmt.Errorf("failed to evaluate correlation '%s' expectation: %w", key, err), c.taskName)
+			}
+		}
+		if known && fmt.Sprint(expected) != fmt.Sprint(value) {
+			return nil, false, nil
+		}
+		keys[key] = value
+	}
+	return keys, true, nil
+}
+
+// output is the list of the consumed events, in the order they were consumed.
+func (c *eventConsumer) output() []interface{} {
+	output := make([]interface{}, 0, len(c.consumed))
+	for _, event := range c.consumed {
+		output = append(output, event.Attributes())
+	}
+	return output
+}
+
+// matchEventValue compares a filter value with an event attribute. Strings match equal values or, as regular
+// expressions, the whole attribute. Objects match attributes holding at least their properties.
+func matchEventValue(expected, actual interface{}) bool {
+	switch e := expected.(type) {
+	case string:
+		a, ok := actual.(string)
+		if !ok {
+			return false
+		}
+		if e == a {
+			return true
+		}
+		pattern, err := regexp.Compile("^(?:" + e + ")$")
+		return err == nil && pattern.MatchString(a)
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for key, value := range e {
+			if !matchEventValue(value, a[key]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return actual != nil && fmt.Sprint(expected) == fmt.Sprint(actual)
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package events holds the CloudEvents representation used by the runner, together with the publishers and
+// subscribers that move events in and out of workflows.
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// SpecVersion is the CloudEvents specification version of the events created by the runner.
+const SpecVersion = "1.0"
+
+const (
+	attrID              = "id"
+	attrSource          = "source"
+	attrSpecVersion     = "specversion"
+	attrType            = "type"
+	attrTime            = "time"
+	attrSubject         = "subject"
+	attrDataContentType = "datacontenttype"
+	attrDataSchema      = "dataschema"
+	attrData            = "data"
+)
+
+// Event is a CloudEvent. It marshals to and from the structured JSON format, where extension attributes
+// are siblings of the context attributes.
+type Event struct {
+	ID              string
+	Source          string
+	SpecVersion     string
+	Type            string
+	Time            time.Time
+	Subject         string
+	DataContentType string
+	DataSchema      string
+	Data            interface{}
+	Extensions      map[string]interface{}
+}
+
+// Attributes returns the event as a plain object, the form exposed to runtime expressions and event filters.
+func (e *Event) Attributes() map[string]interface{} {
+	attributes := make(map[string]interface{}, len(e.Extensions)+9)
+	for name, value := range e.Extensions {
+		attributes[name] = value
+	}
+	attributes[attrID] = e.ID
+	attributes[attrSource] = e.Source
+	attributes[attrSpecVersion] = e.SpecVersion
+	attributes[attrType] = e.Type
+	if !e.Time.IsZero() {
+		attributes[attrTime] = e.Time.Format(time.RFC3339Nano)
+	}
+	if e.Subject != "" {
+		attributes[attrSubject] = e.Subject
+	}
+	if e.DataContentType != "" {
+		attributes[attrDataContentType] = e.DataContentType
+	}
+	if e.DataSchema != "" {
+		attributes[attrDataSchema] = e.DataSchema
+	}
+	if e.Data != nil {
+		attributes[attrData] = e.Data
+	}
+	return attributes
+}
+
+// Validate checks the attributes required by the CloudEvents specification.
+func (e *Event) Validate() error {
+	switch {
+	case e.ID == "":
+		return fmt.Errorf("event attribute '%s' is required", attrID)
+	case e.Source == "":
+		return fmt.Errorf("event attribute '%s' is required", attrSource)
+	case e.Type == "":
+		return fmt.Errorf("event attribute '%s' is required", attrType)
+	case e.SpecVersion == "":
+		return fmt.Errorf("event attribute '%s' is required", attrSpecVersion)
+	}
+	return nil
+}
+
+func (e *Event) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.Attributes())
+}
+
+func (e *Event) UnmarshalJSON(data []byte) error {
+	var attributes map[string]interface{}
+	if err := json.Unmarshal(data, &attributes); err != nil {
+		return fmt.Errorf("failed to unmarshal CloudEvent: %w", err)
+	}
+	event, err := FromAttributes(attributes)
+	if err != nil {
+		return err
+	}
+	*e = *event
+	return nil
+}
+
+// FromAttributes builds an event from its plain object form, the inverse of Attributes.
+func FromAttributes(attributes map[string]interface{}) (*Event, error) {
+	event := &Event{Extensions: map[string]interface{}{}}
+	for name, value := range attributes {
+		if name == attrData {
+			event.Data = value
+			continue
+		}
+		var target *string
+		switch name {
+		case attrID:
+			target = &event.ID
+		case attrSource:
+			target = &event.Source
+		case attrSpecVersion:
+			target = &event.SpecVersion
+		case attrType:
+			target = &event.Type
+		case attrSubject:
+			target = &event.Subject
+		case attrDataContentType:
+			target = &event.DataContentType
+		case attrDataSchema:
+			target = &event.DataSchema
+		case attrTime:
+			timestamp, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("event attribute '%s' must be a string, got %T", name, value)
+			}
+			parsed, err := time.Parse(time.RFC3339Nano, timestamp)
+			if err != nil {
+				return nil, fmt.Errorf("event attribute '%s' must be a RFC 3339 timestamp: %w", name, err)
+			}
+			event.Time = parsed
+			continue
+		default:
+			event.Extensions[name] = value
+			continue
+		}
+		text, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("event attribute '%s' must be a string, got %T", name, value)
+		}
+		*target = text
+	}
+	if len(event.Extensions) == 0 {
+		event.Extensions = nil
+	}
+	return event, nil
+}
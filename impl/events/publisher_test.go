@@ -0,0 +1,93 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestEvent(id string) *Event {
+	return &Event{
+		ID:          id,
+		Source:      "/test/publisher",
+		SpecVersion: SpecVersion,
+		Type:        "com.example.order.placed.v1",
+		Time:        time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+		Data:        map[string]interface{}{"orderId": "42"},
+		Extensions:  map[string]interface{}{"tenant": "acme"},
+	}
+}
+
+func TestEvent_JSON(t *testing.T) {
+	event := newTestEvent("1")
+	data, err := json.Marshal(event)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"id": "1",
+		"source": "/test/publisher",
+		"specversion": "1.0",
+		"type": "com.example.order.placed.v1",
+		"time": "2025-01-01T10:00:00Z",
+		"data": {"orderId": "42"},
+		"tenant": "acme"
+	}`, string(data))
+
+	var decoded Event
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, *event, decoded)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"id": 1}`), &decoded))
+	assert.Error(t, (&Event{ID: "1", Source: "/", SpecVersion: SpecVersion}).Validate())
+}
+
+func TestMemoryPublisher(t *testing.T) {
+	publisher := NewMemoryPublisher()
+	assert.NoError(t, publisher.Publish(context.Background(), newTestEvent("1")))
+	assert.NoError(t, publisher.Publish(context.Background(), newTestEvent("2")))
+
+	published := publisher.Events()
+	assert.Len(t, published, 2)
+	assert.Equal(t, "1", published[0].ID)
+	assert.Equal(t, "2", published[1].ID)
+}
+
+func TestFilePublisher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	publisher, err := NewFilePublisher(path)
+	assert.NoError(t, err)
+	assert.NoError(t, publisher.Publish(context.Background(), newTestEvent("1")))
+	assert.NoError(t, publisher.Publish(context.Background(), newTestEvent("2")))
+	assert.NoError(t, publisher.Close())
+
+	file, err := os.Open(path)
+	assert.NoError(t, err)
+	defer file.Close()
+	var ids []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var event Event
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		ids = append(ids, event.ID)
+	}
+	assert.Equal(t, []string{"1", "2"}, ids)
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+	"errors"
+
+	"github.com/serverlessworkflow/sdk-go/v3/impl/utils"
+)
+
+// ErrSubscriptionClosed is returned when publishing to, or receiving from, a closed subscription.
+var ErrSubscriptionClosed = errors.New("subscription closed")
+
+// Subscriber delivers the events received from a broker to the workflows listening for them.
+type Subscriber interface {
+	// Subscribe starts receiving events until the subscription is closed. Events published before
+	// the subscription is created are not delivered.
+	Subscribe(ctx context.Context) (Subscription, error)
+}
+
+// Subscription is an open stream of events.
+type Subscription interface {
+	Events() <-chan *Event
+	Close() error
+}
+
+var (
+	_ Publisher  = &MemoryBus{}
+	_ Subscriber = &MemoryBus{}
+)
+
+// memoryBusBuffer is how many events a subscription holds before publishing blocks.
+const memoryBusBuffer = 128
+
+// MemoryBus is an in-process broker: every published event is delivered to all the open subscriptions.
+// It lets workflows emitting and listening for events be run, and tested, without an actual broker.
+type MemoryBus struct {
+	fanOut *utils.FanOut[*Event]
+}
+
+func NewMemoryBus() *MemoryBus {
+	return &MemoryBus{fanOut: utils.NewFanOut[*Event](memoryBusBuffer)}
+}
+
+// Publish delivers the event to every open subscription, waiting for room in the ones that are full. Subscriptions
+// closed meanwhile are skipped.
+func (b *MemoryBus) Publish(ctx context.Context, event *Event) error {
+	return b.fanOut.Publish(ctx, event)
+}
+
+func (b *MemoryBus) Subscribe(ctx context.Context) (Subscription, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return &memorySubscription{b.fanOut.Subscribe()}, nil
+}
+
+// Subscribers returns the number of open subscriptions, e.g. to wait until a workflow is listening.
+func (b *MemoryBus) Subscribers() int {
+	return b.fanOut.Subscribers()
+}
+
+type memorySubscription struct {
+	*utils.FanOutSubscription[*Event]
+}
+
+func (s *memorySubscription) Events() <-chan *Event {
+	return s.Values()
+}
+
+func (s *memorySubscription) Close() error {
+	s.FanOutSubscription.Close()
+	return nil
+}
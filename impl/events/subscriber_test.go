@@ -0,0 +1,79 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryBus(t *testing.T) {
+	bus := NewMemoryBus()
+	first, err := bus.Subscribe(context.Background())
+	assert.NoError(t, err)
+	second, err := bus.Subscribe(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, bus.Subscribers())
+
+	event := &Event{ID: "1", Source: "/test", Type: "com.example.ping.v1", SpecVersion: SpecVersion}
+	assert.NoError(t, bus.Publish(context.Background(), event))
+	assert.Same(t, event, <-first.Events())
+	assert.Same(t, event, <-second.Events())
+
+	assert.NoError(t, first.Close())
+	assert.NoError(t, first.Close())
+	assert.Equal(t, 1, bus.Subscribers())
+	_, open := <-first.Events()
+	assert.False(t, open)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = bus.Subscribe(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestMemoryBus_CloseWhilePublishing(t *testing.T) {
+	bus := NewMemoryBus()
+	subscription, err := bus.Subscribe(context.Background())
+	assert.NoError(t, err)
+	event := &Event{ID: "1", Source: "/test", Type: "com.example.ping.v1", SpecVersion: SpecVersion}
+	for i := 0; i < memoryBusBuffer; i++ {
+		assert.NoError(t, bus.Publish(context.Background(), event))
+	}
+
+	published := make(chan error, 1)
+	go func() {
+		published <- bus.Publish(context.Background(), event)
+	}()
+	// let the publisher block on the full subscription
+	time.Sleep(10 * time.Millisecond)
+
+	closed := make(chan error, 1)
+	go func() {
+		closed <- subscription.Close()
+	}()
+	for _, done := range []chan error{closed, published} {
+		select {
+		case err := <-done:
+			assert.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("closing a full subscription deadlocked with the publisher")
+		}
+	}
+	assert.Equal(t, 0, bus.Subscribers())
+}
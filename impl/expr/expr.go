@@ -28,7 +28,7 @@ func TraverseAndEvaluateWithVars(node interface{}, input interface{}, variables
 	if err := mergeContextInVars(nodeContext, variables); err != nil {
 		return nil, err
 	}
-	return traverseAndEvaluate(node, input, variables)
+	return traverseAndEvaluate(nodeContext, node, input, variables)
 }
 
 // TraverseAndEvaluate recursively processes and evaluates all expressions in a JSON-like structure
@@ -36,12 +36,12 @@ func TraverseAndEvaluate(node interface{}, input interface{}, nodeContext contex
 	return TraverseAndEvaluateWithVars(node, input, map[string]interface{}{}, nodeContext)
 }
 
-func traverseAndEvaluate(node interface{}, input interface{}, variables map[string]interface{}) (interface{}, error) {
+func traverseAndEvaluate(nodeContext context.Context, node interface{}, input interface{}, variables map[string]interface{}) (interface{}, error) {
 	switch v := node.(type) {
 	case map[string]interface{}:
 		// Traverse map
 		for key, value := range v {
-			evaluatedValue, err := traverseAndEvaluate(value, input, variables)
+			evaluatedValue, err := traverseAndEvaluate(nodeContext, value, input, variables)
 			if err != nil {
 				return nil, err
 			}
@@ -52,7 +52,7 @@ func traverseAndEvaluate(node interface{}, input interface{}, variables map[stri
 	case []interface{}:
 		// Traverse array
 		for i, value := range v {
-			evaluatedValue, err := traverseAndEvaluate(value, input, variables)
+			evaluatedValue, err := traverseAndEvaluate(nodeContext, value, input, variables)
 			if err != nil {
 				return nil, err
 			}
@@ -63,7 +63,7 @@ func traverseAndEvaluate(node interface{}, input interface{}, variables map[stri
 	case string:
 		// Check if the string is a runtime expression (e.g., ${ .some.path })
 		if model.IsStrictExpr(v) {
-			return evaluateJQExpression(model.SanitizeExpr(v), input, variables)
+			return evaluateJQExpression(nodeContext, model.SanitizeExpr(v), input, variables)
 		}
 		return v, nil
 
@@ -73,8 +73,8 @@ func traverseAndEvaluate(node interface{}, input interface{}, variables map[stri
 	}
 }
 
-// evaluateJQExpression evaluates a jq expression against a given JSON input
-func evaluateJQExpression(expression string, input interface{}, variables map[string]interface{}) (interface{}, error) {
+// evaluateJQExpression evaluates a jq expression against a given JSON input, until the context is done
+func evaluateJQExpression(nodeContext context.Context, expression string, input interface{}, variables map[string]interface{}) (interface{}, error) {
 	query, err := gojq.Parse(expression)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse jq expression: %s, error: %w", expression, err)
@@ -88,7 +88,10 @@ func evaluateJQExpression(expression string, input interface{}, variables map[st
 		return nil, fmt.Errorf("failed to compile jq expression: %s, error: %w", expression, err)
 	}
 
-	iter := code.Run(input, values...)
+	if nodeContext == nil {
+		nodeContext = context.Background()
+	}
+	iter := code.RunWithContext(nodeContext, input, values...)
 	result, ok := iter.Next()
 	if !ok {
 		return nil, errors.New("no result from jq evaluation")
@@ -16,6 +16,7 @@ package expr
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -261,3 +262,13 @@ func exprGetVariableValues(variables map[string]interface{}) []interface{} {
 	}
 	return vals
 }
+
+func TestTraverseAndEvaluate_Cancelled(t *testing.T) {
+	nodeContext, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := TraverseAndEvaluate("${ [range(100000000)] | length }", nil, nodeContext)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("TraverseAndEvaluate() error = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+
+	"github.com/serverlessworkflow/sdk-go/v3/impl/expr"
+	"github.com/serverlessworkflow/sdk-go/v3/impl/utils"
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+)
+
+const extendAll = "all"
+
+// extensionCtxKey marks the context of the tasks run by an extension, which are not extended themselves.
+type extensionCtxKey struct{}
+
+// taskExtensions returns the extensions of the workflow that apply to the task, in declaration order: those extending
+// its type, or all tasks, whose `when` condition holds for the task input.
+func taskExtensions(task *model.TaskItem, input interface{}, taskSupport TaskSupport) ([]*model.Extension, error) {
+	workflow := taskSupport.GetWorkflowDef()
+	if workflow == nil || workflow.Use == nil || len(workflow.Use.Extensions) == 0 {
+		return nil, nil
+	}
+	if extending, _ := taskSupport.GetContext().Value(extensionCtxKey{}).(bool); extending {
+		return nil, nil
+	}
+	taskType := extensionTaskType(task.Task)
+	var extensions []*model.Extension
+	for _, item := range workflow.Use.Extensions {
+		extension := item.Extension
+		if extension == nil || (extension.Extend != extendAll && extension.Extend != taskType) {
+			continue
+		}
+		if extension.When != nil {
+			applies, err := expr.TraverseAndEvaluateBool(model.NormalizeExpr(extension.When.String()), input, taskSupport.GetContext())
+			if err != nil {
+				return nil, model.NewErrExpression(err, task.Key)
+			}
+			if !applies {
+				continue
+			}
+		}
+		extensions = append(extensions, extension)
+	}
+	return extensions, nil
+}
+
+// runExtensions runs the `before` or `after` tasks of the extensions with the given input. Extensions observe the
+// extended task without altering its data: the output of their tasks is discarded.
+func runExtensions(extensions []*model.Extension, after bool, input interface{}, taskSupport TaskSupport) error {
+	if len(extensions) == 0 {
+		return nil
+	}
+	// the position of extensions is not tracked: they run again, from their start, along with the extended task
+	untracked := trackerOf(taskSupport).untracked()
+	extending := untracked.WithContext(context.WithValue(taskSupport.GetContext(), extensionCtxKey{}, true))
+	for _, extension := range extensions {
+		tasks := extension.Before
+		if after {
+			tasks = extension.After
+		}
+		if tasks == nil {
+			continue
+		}
+		if _, err := (&DoTaskRunner{TaskList: tasks}).Run(utils.DeepCloneValue(input), extending); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// extensionTaskType returns the type of the task as named by the `extend` property of extensions.
+func extensionTaskType(task model.Task) string {
+	switch task.(type) {
+	case *model.CallHTTP, *model.CallOpenAPI, *model.CallGRPC, *model.CallAsyncAPI, *model.CallFunction:
+		return "call"
+	case *model.DoTask, *model.ForkTask:
+		return "composite"
+	case *model.EmitTask:
+		return "emit"
+	case *model.ForTask:
+		return "for"
+	case *model.ListenTask:
+		return "listen"
+	case *model.RaiseTask:
+		return "raise"
+	case *model.RunTask:
+		return "run"
+	case *model.SetTask:
+		return "set"
+	case *model.SwitchTask:
+		return "switch"
+	case *model.TryTask:
+		return "try"
+	case *model.WaitTask:
+		return "wait"
+	}
+	return ""
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v3/impl/events"
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/serverlessworkflow/sdk-go/v3/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtensions(t *testing.T) {
+	bus := events.NewMemoryBus()
+	subscription, err := bus.Subscribe(context.Background())
+	assert.NoError(t, err)
+	defer subscription.Close()
+
+	workflow, err := parser.FromFile("./testdata/extensions.yaml")
+	assert.NoError(t, err)
+	runner, err := NewDefaultRunner(workflow, WithEventPublisher(bus))
+	assert.NoError(t, err)
+	output, err := runner.Run(map[string]interface{}{"audited": true})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"step": float64(2)}, output)
+
+	expected := []struct {
+		eventType string
+		data      interface{}
+	}{
+		{"io.test.audit.before", map[string]interface{}{"audited": true}},
+		{"io.test.log", map[string]interface{}{"audited": true}},
+		{"io.test.audit.after", map[string]interface{}{"audited": false, "step": float64(1)}},
+		{"io.test.log", map[string]interface{}{"audited": false, "step": float64(1)}},
+	}
+	for _, e := range expected {
+		event := <-subscription.Events()
+		assert.Equal(t, e.eventType, event.Type)
+		assert.Equal(t, e.data, event.Data)
+	}
+	assert.Empty(t, subscription.Events())
+}
+
+func TestExtensionTaskType(t *testing.T) {
+	assert.Equal(t, "call", extensionTaskType(&model.CallFunction{}))
+	assert.Equal(t, "composite", extensionTaskType(&model.ForkTask{}))
+	assert.Equal(t, "switch", extensionTaskType(&model.SwitchTask{}))
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/serverlessworkflow/sdk-go/v3/impl/auth"
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+)
+
+// loadExternalResource reads the content of an external resource, such as an OpenAPI document, from a local file
+// (`file://`) or over HTTP, authenticating with the endpoint's policy if any. It also returns the resolved location
+// of the resource, which relative references found in the content are resolved against.
+func loadExternalResource(resource *model.ExternalResource, input interface{}, taskName string, taskSupport TaskSupport) ([]byte, *url.URL, error) {
+	if resource == nil {
+		return nil, nil, model.NewErrValidation(fmt.Errorf("missing external resource for task %s", taskName), taskName)
+	}
+	uri, err := evaluateEndpoint(resource.Endpoint, input, taskName, taskSupport)
+	if err != nil {
+		return nil, nil, err
+	}
+	location, err := url.Parse(uri)
+	if err != nil {
+		return nil, nil, model.NewErrValidation(fmt.Errorf("invalid external resource URI %s: %w", uri, err), taskName)
+	}
+
+	switch location.Scheme {
+	case "file":
+		content, err := os.ReadFile(filepath.FromSlash(path.Clean(location.Path)))
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil, model.NewErrConfiguration(fmt.Errorf("external resource %s not found", uri), taskName)
+		}
+		if err != nil {
+			return nil, nil, model.NewErrRuntime(fmt.Errorf("failed to read external resource %s: %w", uri, err), taskName)
+		}
+		return content, location, nil
+	case "http", "https":
+		var authentication *model.ReferenceableAuthenticationPolicy
+		if resource.Endpoint.EndpointConfig != nil {
+			authentication = resource.Endpoint.EndpointConfig.Authentication
+		}
+		client, err := newAuthenticatedHTTPClient(authentication, input, taskName, taskSupport)
+		if err != nil {
+			return nil, nil, err
+		}
+		content, err := fetchExternalResource(client, location, authentication != nil, taskName, taskSupport)
+		if err != nil {
+			return nil, nil, err
+		}
+		return content, location, nil
+	}
+	return nil, nil, model.NewErrConfiguration(fmt.Errorf("unsupported external resource URI %s, must be a file:// or http(s):// URI", uri), taskName)
+}
+
+func fetchExternalResource(client *http.Client, location *url.URL, authenticated bool, taskName string, taskSupport TaskSupport) ([]byte, error) {
+	req, err := http.NewRequestWithContext(taskSupport.GetContext(), http.MethodGet, location.String(), nil)
+	if err != nil {
+		return nil, model.NewErrRuntime(fmt.Errorf("failed to build request for external resource %s: %w", location, err), taskName)
+	}
+	resp, err := client.Do(req)
+	if errors.Is(err, auth.ErrAuthenticationFailed) {
+		return nil, model.NewErrAuthentication(err, taskName)
+	}
+	if err != nil {
+		return nil, model.NewErrCommunication(fmt.Errorf("failed to load external resource %s: %w", location, err), taskName)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, newHTTPStatusError(req, resp, authenticated, taskName)
+	}
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, model.NewErrCommunication(fmt.Errorf("failed to read external resource %s: %w", location, err), taskName)
+	}
+	return content, nil
+}
@@ -0,0 +1,320 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/serverlessworkflow/sdk-go/v3/impl/ctx"
+)
+
+var (
+	// ErrInstanceNotRunning is returned when controlling an instance that already completed, faulted or was cancelled.
+	ErrInstanceNotRunning = errors.New("workflow instance is not running")
+	// ErrInstanceNotSuspended is returned when resuming an instance that is not suspended.
+	ErrInstanceNotSuspended = errors.New("workflow instance is not suspended")
+	// ErrInstanceStarted is returned when starting an instance that already ran, or is running.
+	ErrInstanceStarted = errors.New("workflow instance already started")
+)
+
+// WorkflowInstance controls a workflow instance while it runs, e.g. from another goroutine than the one blocked in
+// WorkflowRunner.Run.
+type WorkflowInstance interface {
+	// Suspend pauses the instance at the next task boundary, until resumed. Tasks already running, e.g. in `fork`
+	// branches, run to completion first.
+	Suspend() error
+	// Resume continues a suspended instance from the task boundary it paused at.
+	Resume() error
+	// Cancel stops the instance, interrupting its running tasks, waits and suspension.
+	Cancel() error
+}
+
+// WorkflowHandle follows and controls an instance started in the background by WorkflowRunner.Start.
+type WorkflowHandle interface {
+	WorkflowInstance
+	// InstanceID returns the identifier of the instance, also known as `$workflow.id`.
+	InstanceID() string
+	// Status returns the current status of the instance.
+	Status() ctx.StatusPhase
+	// TaskReference returns the JSON Pointer of the task the instance is running, empty once it ended.
+	TaskReference() string
+	// Wait blocks until the instance ends, returning its output or error, or until the context is done, returning the
+	// context error without affecting the instance.
+	Wait(ctx context.Context) (interface{}, error)
+	// Done returns a channel closed once the instance ends.
+	Done() <-chan struct{}
+}
+
+// instanceControl holds the suspension and cancellation requests of an instance, shared by the copies of its runner,
+// e.g. the ones running `fork` branches.
+type instanceControl struct {
+	mu sync.Mutex
+	// resumed is open while a suspension is requested, closed on resume
+	resumed chan struct{}
+	// paused counts the task boundaries waiting for the instance to be resumed
+	paused    int
+	cancel    context.CancelFunc
+	cancelled bool
+	started   bool
+	// released is set once the run ended, its context being cancelled to free it
+	released bool
+	// detached is the context of the work outliving the tasks, created on first use: it is cancelled along with the
+	// instance, but not when the run ends
+	detached     context.Context
+	stopDetached context.CancelFunc
+	// runCtx is the context of the run the detached one follows until the run ends
+	runCtx context.Context
+	// setStatus records the status transitions of the instance
+	setStatus func(status ctx.StatusPhase)
+	// parent controls the instance running this one as a sub-workflow, if any: suspending it suspends this one too
+	parent *instanceControl
+}
+
+func newInstanceControl(cancel context.CancelFunc, setStatus func(status ctx.StatusPhase), parent *instanceControl) *instanceControl {
+	return &instanceControl{cancel: cancel, setStatus: setStatus, parent: parent}
+}
+
+func (c *instanceControl) suspend() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.resumed == nil {
+		c.resumed = make(chan struct{})
+	}
+}
+
+func (c *instanceControl) resume() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.resumed == nil {
+		return ErrInstanceNotSuspended
+	}
+	close(c.resumed)
+	c.resumed = nil
+	if c.paused > 0 {
+		c.paused = 0
+		c.setStatus(ctx.RunningStatus)
+	}
+	return nil
+}
+
+func (c *instanceControl) doCancel() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cancelled = true
+	c.cancel()
+}
+
+// bind replaces the function cancelling the instance once its context is derived from the caller's one.
+func (c *instanceControl) bind(cancel context.CancelFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cancel()
+	c.cancel = cancel
+	if c.cancelled {
+		cancel()
+	}
+}
+
+// release cancels the context of the run once it ended, leaving the work outliving it running.
+func (c *instanceControl) release() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.released = true
+	if c.detached != nil && c.runCtx.Err() != nil {
+		// the run ended as it was cancelled, maybe before the detached context followed
+		c.stopDetached()
+	}
+	c.cancel()
+}
+
+// detach returns the context of the work outliving the tasks of the run, e.g. processes not awaited.
+func (c *instanceControl) detach(runCtx context.Context) context.Context {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.detached == nil {
+		detached, stop := context.WithCancel(context.WithoutCancel(runCtx))
+		context.AfterFunc(runCtx, func() {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			if !c.released {
+				stop()
+			}
+		})
+		c.detached, c.stopDetached, c.runCtx = detached, stop, runCtx
+	}
+	return c.detached
+}
+
+// start reports whether the instance is started for the first time.
+func (c *instanceControl) start() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	started := c.started
+	c.started = true
+	return !started
+}
+
+func (c *instanceControl) isCancelled() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.cancelled
+}
+
+// pause returns the channel closed once the instance is resumed, nil when no suspension is requested. The first task
+// boundary reached after the request moves the instance to the suspended status.
+func (c *instanceControl) pause() <-chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.resumed == nil {
+		return nil
+	}
+	if c.paused == 0 {
+		c.setStatus(ctx.SuspendedStatus)
+	}
+	c.paused++
+	return c.resumed
+}
+
+// awaitResume blocks while this instance, or any of its parents, is suspended. It returns the context error when the
+// context is done meanwhile.
+func (c *instanceControl) awaitResume(runCtx context.Context) error {
+	for control := c; control != nil; control = control.parent {
+		for resumed := control.pause(); resumed != nil; resumed = control.pause() {
+			select {
+			case <-runCtx.Done():
+				return runCtx.Err()
+			case <-resumed:
+			}
+		}
+	}
+	return runCtx.Err()
+}
+
+// isCancelled reports whether the instance was cancelled, by Cancel or by the caller's context.
+func (wr *workflowRunnerImpl) isCancelled() bool {
+	return wr.control.isCancelled() || errors.Is(wr.Context.Err(), context.Canceled)
+}
+
+func isFinalStatus(status ctx.StatusPhase) bool {
+	return status == ctx.CompletedStatus || status == ctx.FaultedStatus || status == ctx.CancelledStatus
+}
+
+func (wr *workflowRunnerImpl) Suspend() error {
+	if isFinalStatus(wr.RunnerCtx.GetStatus()) {
+		return ErrInstanceNotRunning
+	}
+	wr.control.suspend()
+	return nil
+}
+
+func (wr *workflowRunnerImpl) Resume() error {
+	if isFinalStatus(wr.RunnerCtx.GetStatus()) {
+		return ErrInstanceNotRunning
+	}
+	return wr.control.resume()
+}
+
+func (wr *workflowRunnerImpl) Cancel() error {
+	if isFinalStatus(wr.RunnerCtx.GetStatus()) {
+		return ErrInstanceNotRunning
+	}
+	wr.control.doCancel()
+	return nil
+}
+
+func (wr *workflowRunnerImpl) AwaitResume() error {
+	return wr.control.awaitResume(wr.Context)
+}
+
+func (wr *workflowRunnerImpl) Start(parent context.Context, input interface{}) (WorkflowHandle, error) {
+	if !wr.control.start() {
+		return nil, ErrInstanceStarted
+	}
+	wr.bindContext(parent)
+	return wr.runInBackground(func() (interface{}, error) {
+		return wr.run(input)
+	}), nil
+}
+
+// runInBackground runs the instance in its own goroutine, returning the handle following it.
+func (wr *workflowRunnerImpl) runInBackground(run func() (interface{}, error)) *workflowHandle {
+	handle := &workflowHandle{runner: wr, done: make(chan struct{})}
+	go func() {
+		defer close(handle.done)
+		handle.output, handle.err = run()
+	}()
+	return handle
+}
+
+// bindContext derives the context of the instance from the caller's one, so that its deadline, cancellation and
+// values apply to the run. The instance is still cancelled along with the context the runner was created with.
+func (wr *workflowRunnerImpl) bindContext(caller context.Context) {
+	runCtx, cancel := context.WithCancel(caller)
+	stop := context.AfterFunc(wr.parent, cancel)
+	wr.Context = ctx.WithWorkflowContext(runCtx, wr.RunnerCtx)
+	wr.instanceCtx = wr.Context
+	wr.control.bind(func() {
+		stop()
+		cancel()
+	})
+}
+
+// workflowHandle is the WorkflowHandle of an instance run by the default runner.
+type workflowHandle struct {
+	runner *workflowRunnerImpl
+	done   chan struct{}
+	// output and err are set before done is closed
+	output interface{}
+	err    error
+}
+
+func (h *workflowHandle) Suspend() error {
+	return h.runner.Suspend()
+}
+
+func (h *workflowHandle) Resume() error {
+	return h.runner.Resume()
+}
+
+func (h *workflowHandle) Cancel() error {
+	return h.runner.Cancel()
+}
+
+func (h *workflowHandle) InstanceID() string {
+	return h.runner.RunnerCtx.GetInstanceID()
+}
+
+func (h *workflowHandle) Status() ctx.StatusPhase {
+	return h.runner.RunnerCtx.GetStatus()
+}
+
+func (h *workflowHandle) TaskReference() string {
+	return h.runner.RunnerCtx.GetTaskReference()
+}
+
+func (h *workflowHandle) Wait(waitCtx context.Context) (interface{}, error) {
+	select {
+	case <-h.done:
+		return h.output, h.err
+	case <-waitCtx.Done():
+		return nil, waitCtx.Err()
+	}
+}
+
+func (h *workflowHandle) Done() <-chan struct{} {
+	return h.done
+}
@@ -0,0 +1,311 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v3/impl/ctx"
+	"github.com/serverlessworkflow/sdk-go/v3/impl/events"
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/serverlessworkflow/sdk-go/v3/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+type runResult struct {
+	output interface{}
+	err    error
+}
+
+func runAsync(runner WorkflowRunner, input interface{}) <-chan runResult {
+	result := make(chan runResult, 1)
+	go func() {
+		output, err := runner.Run(input)
+		result <- runResult{output: output, err: err}
+	}()
+	return result
+}
+
+// statusChanges lists the statuses published for the workflow, or for the task when set.
+func statusChanges(publisher *events.MemoryPublisher, subject string, task string) []string {
+	var statuses []string
+	for _, event := range publisher.Events() {
+		if event.Type != LifecycleEventType(subject, lifecycleStatusChanged) {
+			continue
+		}
+		data := event.Data.(map[string]interface{})
+		if task == "" || data["task"] == task {
+			statuses = append(statuses, data["status"].(string))
+		}
+	}
+	return statuses
+}
+
+func TestWorkflowInstance_SuspendResume(t *testing.T) {
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		entered <- struct{}{}
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": 1}`))
+	}))
+	defer server.Close()
+
+	workflow, err := parser.FromYAMLSource([]byte(`
+document:
+  dsl: '1.0.0'
+  namespace: test
+  name: suspend
+  version: '1.0.0'
+do:
+  - getPet:
+      call: http
+      with:
+        method: get
+        endpoint: '${ .baseUrl + "/pets/1" }'
+  - mark:
+      set:
+        id: '${ .id }'
+        marked: true
+`))
+	assert.NoError(t, err)
+	publisher := events.NewMemoryPublisher()
+	runner, err := NewDefaultRunner(workflow, WithLifecycleEventPublisher(publisher))
+	assert.NoError(t, err)
+	assert.ErrorIs(t, runner.Resume(), ErrInstanceNotSuspended)
+
+	result := runAsync(runner, map[string]interface{}{"baseUrl": server.URL})
+	<-entered
+	assert.NoError(t, runner.Suspend())
+	close(release)
+
+	assert.Eventually(t, func() bool {
+		return runner.GetWorkflowCtx().GetStatus() == ctx.SuspendedStatus
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, ctx.CompletedStatus, runner.GetWorkflowCtx().GetTaskStatus("getPet"))
+	assert.Empty(t, runner.GetWorkflowCtx().GetTaskStatus("mark"))
+	select {
+	case <-result:
+		t.Fatal("suspended workflow completed")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	assert.NoError(t, runner.Resume())
+	completed := <-result
+	assert.NoError(t, completed.err)
+	assert.Equal(t, map[string]interface{}{"id": float64(1), "marked": true}, completed.output)
+	assert.Equal(t, []string{"running", "suspended", "running", "completed"}, statusChanges(publisher, lifecycleWorkflow, ""))
+	assert.Contains(t, eventTypes(publisher), LifecycleEventType(lifecycleWorkflow, lifecycleResumed))
+
+	assert.ErrorIs(t, runner.Suspend(), ErrInstanceNotRunning)
+	assert.ErrorIs(t, runner.Cancel(), ErrInstanceNotRunning)
+}
+
+func TestWorkflowInstance_Cancel(t *testing.T) {
+	t.Run("suspended instance", func(t *testing.T) {
+		workflow, err := parser.FromYAMLSource([]byte(`
+document:
+  dsl: '1.0.0'
+  namespace: test
+  name: cancel
+  version: '1.0.0'
+do:
+  - greet:
+      set:
+        greeting: hello
+`))
+		assert.NoError(t, err)
+		publisher := events.NewMemoryPublisher()
+		runner, err := NewDefaultRunner(workflow, WithLifecycleEventPublisher(publisher))
+		assert.NoError(t, err)
+		assert.NoError(t, runner.Suspend())
+
+		result := runAsync(runner, nil)
+		assert.Eventually(t, func() bool {
+			return runner.GetWorkflowCtx().GetStatus() == ctx.SuspendedStatus
+		}, time.Second, 5*time.Millisecond)
+		assert.NoError(t, runner.Cancel())
+
+		cancelled := <-result
+		assert.True(t, model.IsErrRuntime(cancelled.err))
+		assert.ErrorContains(t, cancelled.err, "cancelled")
+		assert.Equal(t, []string{"running", "suspended", "cancelled"}, statusChanges(publisher, lifecycleWorkflow, ""))
+		assert.Empty(t, runner.GetWorkflowCtx().GetTaskStatus("greet"))
+	})
+
+	t.Run("fork branches, for loops and waits", func(t *testing.T) {
+		workflow, err := parser.FromYAMLSource([]byte(`
+document:
+  dsl: '1.0.0'
+  namespace: test
+  name: cancel
+  version: '1.0.0'
+do:
+  - branches:
+      fork:
+        compete: false
+        branches:
+          - pause:
+              wait: PT1H
+          - loop:
+              for:
+                each: item
+                in: '${ [1, 2, 3] }'
+              do:
+                - pauseItem:
+                    wait: PT1H
+`))
+		assert.NoError(t, err)
+		publisher := events.NewMemoryPublisher()
+		runner, err := NewDefaultRunner(workflow, WithLifecycleEventPublisher(publisher))
+		assert.NoError(t, err)
+
+		result := runAsync(runner, nil)
+		assert.Eventually(t, func() bool {
+			return len(statusChanges(publisher, lifecycleTask, "/do/0/branches/fork/branches/0/pause")) == 1 &&
+				len(statusChanges(publisher, lifecycleTask, "/do/0/branches/fork/branches/1/loop/do/0/pauseItem")) == 3
+		}, time.Second, 5*time.Millisecond)
+		assert.NoError(t, runner.Cancel())
+
+		select {
+		case cancelled := <-result:
+			assert.True(t, model.IsErrRuntime(cancelled.err))
+			assert.ErrorContains(t, cancelled.err, "cancelled")
+		case <-time.After(time.Second):
+			t.Fatal("cancelled workflow is still running")
+		}
+		assert.Equal(t, ctx.CancelledStatus, runner.GetWorkflowCtx().GetStatus())
+		assert.Equal(t, ctx.CancelledStatus, runner.GetWorkflowCtx().GetTaskStatus("branches"))
+		assert.Equal(t, []string{"running", "cancelled"}, statusChanges(publisher, lifecycleWorkflow, ""))
+	})
+}
+
+func eventTypes(publisher *events.MemoryPublisher) []string {
+	var types []string
+	for _, event := range publisher.Events() {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+func TestWorkflowRunner_Start(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/blocked/pets/1" {
+			<-r.Context().Done()
+			return
+		}
+		select {
+		case <-release:
+		case <-r.Context().Done():
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": 1}`))
+	}))
+	defer server.Close()
+
+	workflow, err := parser.FromYAMLSource([]byte(`
+document:
+  dsl: '1.0.0'
+  namespace: test
+  name: start
+  version: '1.0.0'
+do:
+  - getPet:
+      call: http
+      with:
+        method: get
+        endpoint: '${ .baseUrl + "/pets/1" }'
+`))
+	assert.NoError(t, err)
+	input := map[string]interface{}{"baseUrl": server.URL}
+
+	t.Run("completed instance", func(t *testing.T) {
+		runner, err := NewDefaultRunner(workflow)
+		assert.NoError(t, err)
+		handle, err := runner.Start(context.Background(), input)
+		assert.NoError(t, err)
+		_, err = runner.Start(context.Background(), input)
+		assert.ErrorIs(t, err, ErrInstanceStarted)
+		_, err = runner.Run(input)
+		assert.ErrorIs(t, err, ErrInstanceStarted)
+		// the running instance keeps its context
+		cancelled, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = runner.RunWithContext(cancelled, input)
+		assert.ErrorIs(t, err, ErrInstanceStarted)
+
+		assert.Equal(t, runner.GetWorkflowCtx().GetInstanceID(), handle.InstanceID())
+		assert.Eventually(t, func() bool {
+			return handle.TaskReference() == "/do/0/getPet"
+		}, time.Second, 5*time.Millisecond)
+		assert.Equal(t, ctx.RunningStatus, handle.Status())
+		waitCtx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err = handle.Wait(waitCtx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, ctx.RunningStatus, handle.Status())
+
+		close(release)
+		<-handle.Done()
+		output, err := handle.Wait(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"id": float64(1)}, output)
+		assert.Equal(t, ctx.CompletedStatus, handle.Status())
+		assert.Empty(t, handle.TaskReference())
+		assert.ErrorIs(t, runner.(*workflowRunnerImpl).GetContext().Err(), context.Canceled)
+	})
+
+	t.Run("instance cancelled along with the context", func(t *testing.T) {
+		runner, err := NewDefaultRunner(workflow)
+		assert.NoError(t, err)
+		startCtx, cancel := context.WithCancel(context.Background())
+		handle, err := runner.Start(startCtx, map[string]interface{}{"baseUrl": server.URL + "/blocked"})
+		assert.NoError(t, err)
+		assert.Eventually(t, func() bool {
+			return handle.TaskReference() == "/do/0/getPet"
+		}, time.Second, 5*time.Millisecond)
+
+		cancel()
+		_, err = handle.Wait(context.Background())
+		assert.Error(t, err)
+		assert.Equal(t, ctx.CancelledStatus, handle.Status())
+		assert.ErrorIs(t, handle.Cancel(), ErrInstanceNotRunning)
+	})
+
+	t.Run("instance runs once", func(t *testing.T) {
+		runner, err := NewDefaultRunner(workflow)
+		assert.NoError(t, err)
+		_, err = runner.Run(input)
+		assert.NoError(t, err)
+		assert.Equal(t, ctx.CompletedStatus, runner.GetWorkflowCtx().GetStatus())
+		// the context of the instance is released once it ran
+		assert.ErrorIs(t, runner.(*workflowRunnerImpl).GetContext().Err(), context.Canceled)
+
+		_, err = runner.Run(input)
+		assert.ErrorIs(t, err, ErrInstanceStarted)
+		_, err = runner.RunWithContext(context.Background(), input)
+		assert.ErrorIs(t, err, ErrInstanceStarted)
+		_, err = runner.Start(context.Background(), input)
+		assert.ErrorIs(t, err, ErrInstanceStarted)
+		assert.Equal(t, ctx.CompletedStatus, runner.GetWorkflowCtx().GetStatus())
+	})
+}
@@ -0,0 +1,203 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/serverlessworkflow/sdk-go/v3/impl/ctx"
+	"github.com/serverlessworkflow/sdk-go/v3/impl/events"
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+)
+
+const (
+	lifecycleEventTypePrefix = "io.serverlessworkflow."
+	lifecycleEventTypeSuffix = ".v1"
+	lifecycleWorkflow        = "workflow"
+	lifecycleTask            = "task"
+
+	lifecycleCreated       = "created"
+	lifecycleStarted       = "started"
+	lifecycleSuspended     = "suspended"
+	lifecycleResumed       = "resumed"
+	lifecycleCancelled     = "cancelled"
+	lifecycleFaulted       = "faulted"
+	lifecycleCompleted     = "completed"
+	lifecycleRetried       = "retried"
+	lifecycleStatusChanged = "status-changed"
+)
+
+// LifecycleEventType returns the type of the CloudEvent published when a workflow or a task goes through the
+// lifecycle event, e.g. `io.serverlessworkflow.task.completed.v1`.
+func LifecycleEventType(subject, event string) string {
+	return lifecycleEventTypePrefix + subject + "." + event + lifecycleEventTypeSuffix
+}
+
+// lifecycleEvent names the lifecycle event of a status transition, empty when the transition only changes the status,
+// e.g. a task waiting for an event.
+func lifecycleEvent(previous, status ctx.StatusPhase) string {
+	switch status {
+	case ctx.PendingStatus:
+		return lifecycleCreated
+	case ctx.RunningStatus:
+		switch previous {
+		case "", ctx.PendingStatus:
+			return lifecycleStarted
+		case ctx.SuspendedStatus:
+			return lifecycleResumed
+		}
+	case ctx.SuspendedStatus:
+		return lifecycleSuspended
+	case ctx.CancelledStatus:
+		return lifecycleCancelled
+	case ctx.FaultedStatus:
+		return lifecycleFaulted
+	case ctx.CompletedStatus:
+		return lifecycleCompleted
+	}
+	return ""
+}
+
+// lifecycleOutcome is what a workflow or a task produced when it reached its final status: the output once completed,
+// the error once faulted.
+type lifecycleOutcome struct {
+	output interface{}
+	err    error
+}
+
+// setStatus moves the workflow instance to the status, publishing the matching lifecycle events.
+func (wr *workflowRunnerImpl) setStatus(status ctx.StatusPhase, outcome lifecycleOutcome) {
+	previous := wr.RunnerCtx.GetStatus()
+	wr.RunnerCtx.SetStatus(status)
+	if wr.LifecyclePublisher == nil {
+		return
+	}
+
+	data := map[string]interface{}{"name": wr.RunnerCtx.GetInstanceID()}
+	wr.publishLifecycleEvents(lifecycleWorkflow, lifecycleEvent(previous, status), status, data, outcome)
+}
+
+// statusSetter records the status transitions of this instance that carry no outcome, e.g. suspension.
+func (wr *workflowRunnerImpl) statusSetter() func(status ctx.StatusPhase) {
+	return func(status ctx.StatusPhase) {
+		wr.setStatus(status, lifecycleOutcome{})
+	}
+}
+
+// setTaskStatus moves the task to the status, publishing the matching lifecycle events.
+func (wr *workflowRunnerImpl) setTaskStatus(task string, status ctx.StatusPhase, outcome lifecycleOutcome) {
+	previous := wr.RunnerCtx.GetTaskStatus(task)
+	wr.RunnerCtx.SetTaskStatus(task, status)
+	if wr.LifecyclePublisher == nil {
+		return
+	}
+
+	data := map[string]interface{}{
+		"workflow": wr.RunnerCtx.GetInstanceID(),
+		"task":     wr.taskLifecycleReference(task),
+	}
+	wr.publishLifecycleEvents(lifecycleTask, lifecycleEvent(previous, status), status, data, outcome)
+}
+
+// setTaskRetried publishes the lifecycle event of a task about to run again, as a retry policy requires. The task
+// keeps running meanwhile, its status is unchanged.
+func (wr *workflowRunnerImpl) setTaskRetried(task string) {
+	if wr.LifecyclePublisher == nil {
+		return
+	}
+
+	now := wr.GetClock().Now().UTC()
+	data := map[string]interface{}{
+		"workflow":              wr.RunnerCtx.GetInstanceID(),
+		"task":                  wr.taskLifecycleReference(task),
+		lifecycleRetried + "At": now.Format(time.RFC3339Nano),
+	}
+	_ = wr.LifecyclePublisher.Publish(context.WithoutCancel(wr.Context), wr.newLifecycleEvent(lifecycleTask, lifecycleRetried, now, data))
+}
+
+// taskLifecycleReference returns the JSON Pointer of the task reported by its lifecycle events.
+func (wr *workflowRunnerImpl) taskLifecycleReference(task string) string {
+	// once a task ran, the current reference points to the last task it ran itself, e.g. in a `do` or `for` task
+	reference, err := GenerateJSONPointer(wr.Workflow, task)
+	if err != nil {
+		return wr.RunnerCtx.GetTaskReference()
+	}
+	return reference
+}
+
+// publishLifecycleEvents publishes the lifecycle event of the transition, if any, followed by the status change.
+// Lifecycle events are meant for monitoring: publishing failures never affect the workflow instance.
+func (wr *workflowRunnerImpl) publishLifecycleEvents(subject, event string, status ctx.StatusPhase, data map[string]interface{}, outcome lifecycleOutcome) {
+	now := wr.GetClock().Now().UTC()
+	timestamp := now.Format(time.RFC3339Nano)
+	// a cancelled instance must still report it
+	publishCtx := context.WithoutCancel(wr.Context)
+
+	if event != "" {
+		eventData := make(map[string]interface{}, len(data)+3)
+		for key, value := range data {
+			eventData[key] = value
+		}
+		eventData[event+"At"] = timestamp
+		switch event {
+		case lifecycleStarted:
+			if subject == lifecycleWorkflow {
+				eventData["definition"] = map[string]interface{}{
+					"namespace": wr.Workflow.Document.Namespace,
+					"name":      wr.Workflow.Document.Name,
+					"version":   wr.Workflow.Document.Version,
+				}
+			}
+		case lifecycleFaulted:
+			eventData["error"] = wr.lifecycleError(outcome.err)
+		case lifecycleCompleted:
+			eventData["output"] = outcome.output
+		}
+		_ = wr.LifecyclePublisher.Publish(publishCtx, wr.newLifecycleEvent(subject, event, now, eventData))
+	}
+
+	data["status"] = status.String()
+	data["updatedAt"] = timestamp
+	_ = wr.LifecyclePublisher.Publish(publishCtx, wr.newLifecycleEvent(subject, lifecycleStatusChanged, now, data))
+}
+
+func (wr *workflowRunnerImpl) newLifecycleEvent(subject, event string, now time.Time, data map[string]interface{}) *events.Event {
+	return &events.Event{
+		ID:              uuid.NewString(),
+		Source:          defaultEventSource(wr.Workflow),
+		SpecVersion:     events.SpecVersion,
+		Type:            LifecycleEventType(subject, event),
+		Time:            now,
+		DataContentType: "application/json",
+		Data:            data,
+	}
+}
+
+// lifecycleError describes the error of a faulted workflow or task, with the values of the secrets redacted.
+func (wr *workflowRunnerImpl) lifecycleError(err error) map[string]interface{} {
+	if err == nil {
+		return nil
+	}
+	var described model.Error
+	if workflowErr := model.AsError(err); workflowErr != nil {
+		described = *workflowErr
+	} else {
+		described = *model.NewErrRuntime(err, wr.RunnerCtx.GetTaskReference())
+	}
+	_ = redactSecrets(&described, wr.RunnerCtx.GetSecrets())
+	return errorToValue(&described)
+}
@@ -0,0 +1,182 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v3/impl/events"
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/serverlessworkflow/sdk-go/v3/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func lifecycleEventsOf(t *testing.T, publisher *events.MemoryPublisher) ([]string, map[string]map[string]interface{}) {
+	t.Helper()
+	var types []string
+	data := map[string]map[string]interface{}{}
+	for _, event := range publisher.Events() {
+		assert.NoError(t, event.Validate())
+		assert.Equal(t, "/test/lifecycle", event.Source)
+		types = append(types, event.Type)
+		data[event.Type] = event.Data.(map[string]interface{})
+	}
+	return types, data
+}
+
+func TestLifecycleEvents(t *testing.T) {
+	clock := newFakeClock()
+
+	t.Run("completed workflow", func(t *testing.T) {
+		workflow, err := parser.FromYAMLSource([]byte(`
+document:
+  dsl: '1.0.0'
+  namespace: test
+  name: lifecycle
+  version: '1.0.0'
+do:
+  - greet:
+      set:
+        greeting: hello
+`))
+		assert.NoError(t, err)
+		publisher := events.NewMemoryPublisher()
+		runner, err := NewDefaultRunner(workflow, WithLifecycleEventPublisher(publisher), WithClock(clock))
+		assert.NoError(t, err)
+		_, err = runner.Run(nil)
+		assert.NoError(t, err)
+
+		types, data := lifecycleEventsOf(t, publisher)
+		assert.Equal(t, []string{
+			"io.serverlessworkflow.workflow.started.v1",
+			"io.serverlessworkflow.workflow.status-changed.v1",
+			"io.serverlessworkflow.task.created.v1",
+			"io.serverlessworkflow.task.status-changed.v1",
+			"io.serverlessworkflow.task.started.v1",
+			"io.serverlessworkflow.task.status-changed.v1",
+			"io.serverlessworkflow.task.completed.v1",
+			"io.serverlessworkflow.task.status-changed.v1",
+			"io.serverlessworkflow.workflow.completed.v1",
+			"io.serverlessworkflow.workflow.status-changed.v1",
+		}, types)
+
+		instanceID := runner.GetWorkflowCtx().GetInstanceID()
+		assert.Equal(t, map[string]interface{}{
+			"name":       instanceID,
+			"definition": map[string]interface{}{"namespace": "test", "name": "lifecycle", "version": "1.0.0"},
+			"startedAt":  "2025-01-01T00:00:00Z",
+		}, data["io.serverlessworkflow.workflow.started.v1"])
+		assert.Equal(t, map[string]interface{}{
+			"workflow":    instanceID,
+			"task":        "/do/0/greet",
+			"completedAt": "2025-01-01T00:00:00Z",
+			"output":      map[string]interface{}{"greeting": "hello"},
+		}, data["io.serverlessworkflow.task.completed.v1"])
+		assert.Equal(t, map[string]interface{}{
+			"name":      instanceID,
+			"status":    "completed",
+			"updatedAt": "2025-01-01T00:00:00Z",
+		}, data["io.serverlessworkflow.workflow.status-changed.v1"])
+	})
+
+	t.Run("faulted workflow", func(t *testing.T) {
+		workflow, err := parser.FromYAMLSource([]byte(`
+document:
+  dsl: '1.0.0'
+  namespace: test
+  name: lifecycle
+  version: '1.0.0'
+do:
+  - fail:
+      raise:
+        error:
+          type: https://serverlessworkflow.io/spec/1.0.0/errors/validation
+          status: 400
+          title: Invalid order
+          detail: The order has no items
+`))
+		assert.NoError(t, err)
+		publisher := events.NewMemoryPublisher()
+		runner, err := NewDefaultRunner(workflow, WithLifecycleEventPublisher(publisher), WithClock(clock))
+		assert.NoError(t, err)
+		_, err = runner.Run(nil)
+		assert.True(t, model.IsErrValidation(err))
+
+		types, data := lifecycleEventsOf(t, publisher)
+		assert.Contains(t, types, "io.serverlessworkflow.task.faulted.v1")
+		assert.Equal(t, "io.serverlessworkflow.workflow.status-changed.v1", types[len(types)-1])
+		assert.Equal(t, "io.serverlessworkflow.workflow.faulted.v1", types[len(types)-2])
+
+		taskFaulted := data["io.serverlessworkflow.task.faulted.v1"]
+		assert.Equal(t, "/do/0/fail", taskFaulted["task"])
+		assert.Equal(t, "2025-01-01T00:00:00Z", taskFaulted["faultedAt"])
+		assert.Equal(t, "Invalid order", taskFaulted["error"].(map[string]interface{})["title"])
+		workflowFaulted := data["io.serverlessworkflow.workflow.faulted.v1"]
+		assert.Equal(t, runner.GetWorkflowCtx().GetInstanceID(), workflowFaulted["name"])
+		assert.Equal(t, 400, workflowFaulted["error"].(map[string]interface{})["status"])
+		assert.Equal(t, "/do/0/fail", workflowFaulted["error"].(map[string]interface{})["instance"])
+	})
+
+	t.Run("retried task", func(t *testing.T) {
+		workflow, err := parser.FromYAMLSource([]byte(`
+document:
+  dsl: '1.0.0'
+  namespace: test
+  name: lifecycle
+  version: '1.0.0'
+do:
+  - getPet:
+      try:
+        - fail:
+            raise:
+              error:
+                type: https://serverlessworkflow.io/spec/1.0.0/errors/communication
+                status: 503
+                title: Service unavailable
+                detail: The pet service is down
+      catch:
+        retry:
+          delay:
+            seconds: 1
+          limit:
+            attempt:
+              count: 3
+        do:
+          - fallback:
+              set:
+                pet: none
+`))
+		assert.NoError(t, err)
+		publisher := events.NewMemoryPublisher()
+		runner, err := NewDefaultRunner(workflow, WithLifecycleEventPublisher(publisher), WithClock(newFakeClock()))
+		assert.NoError(t, err)
+		_, err = runner.Run(nil)
+		assert.NoError(t, err)
+
+		types, data := lifecycleEventsOf(t, publisher)
+		retried := 0
+		for _, eventType := range types {
+			if eventType == "io.serverlessworkflow.task.retried.v1" {
+				retried++
+			}
+		}
+		assert.Equal(t, 3, retried)
+		assert.Equal(t, map[string]interface{}{
+			"workflow":  runner.GetWorkflowCtx().GetInstanceID(),
+			"task":      "/do/0/getPet",
+			"retriedAt": "2025-01-01T00:00:03Z",
+		}, data["io.serverlessworkflow.task.retried.v1"])
+	})
+}
@@ -0,0 +1,209 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+const openAPIComponentParameters = "#/components/parameters/"
+const openAPIComponentRequestBodies = "#/components/requestBodies/"
+
+// openAPIDocument holds the parts of an OpenAPI 3.x document needed to invoke its operations.
+type openAPIDocument struct {
+	OpenAPI    string                     `json:"openapi"`
+	Servers    []openAPIServer            `json:"servers,omitempty"`
+	Paths      map[string]openAPIPathItem `json:"paths,omitempty"`
+	Components openAPIComponents          `json:"components,omitempty"`
+}
+
+type openAPIServer struct {
+	URL       string                           `json:"url"`
+	Variables map[string]openAPIServerVariable `json:"variables,omitempty"`
+}
+
+type openAPIServerVariable struct {
+	Default string `json:"default"`
+}
+
+type openAPIComponents struct {
+	Parameters    map[string]openAPIParameter   `json:"parameters,omitempty"`
+	RequestBodies map[string]openAPIRequestBody `json:"requestBodies,omitempty"`
+}
+
+type openAPIPathItem struct {
+	Servers    []openAPIServer    `json:"servers,omitempty"`
+	Parameters []openAPIParameter `json:"parameters,omitempty"`
+	Get        *openAPIOperation  `json:"get,omitempty"`
+	Put        *openAPIOperation  `json:"put,omitempty"`
+	Post       *openAPIOperation  `json:"post,omitempty"`
+	Delete     *openAPIOperation  `json:"delete,omitempty"`
+	Options    *openAPIOperation  `json:"options,omitempty"`
+	Head       *openAPIOperation  `json:"head,omitempty"`
+	Patch      *openAPIOperation  `json:"patch,omitempty"`
+	Trace      *openAPIOperation  `json:"trace,omitempty"`
+}
+
+type openAPIOperation struct {
+	OperationID string              `json:"operationId,omitempty"`
+	Servers     []openAPIServer     `json:"servers,omitempty"`
+	Parameters  []openAPIParameter  `json:"parameters,omitempty"`
+	RequestBody *openAPIRequestBody `json:"requestBody,omitempty"`
+}
+
+type openAPIParameter struct {
+	Ref      string `json:"$ref,omitempty"`
+	Name     string `json:"name,omitempty"`
+	In       string `json:"in,omitempty"`
+	Required bool   `json:"required,omitempty"`
+}
+
+type openAPIRequestBody struct {
+	Ref      string `json:"$ref,omitempty"`
+	Required bool   `json:"required,omitempty"`
+}
+
+// openAPIOperationRef is an operation found in a document, along with the details inherited from its path item.
+type openAPIOperationRef struct {
+	Method      string
+	Path        string
+	Servers     []openAPIServer
+	Parameters  []openAPIParameter
+	RequestBody *openAPIRequestBody
+}
+
+func parseOpenAPIDocument(source []byte) (*openAPIDocument, error) {
+	doc := &openAPIDocument{}
+	if err := yaml.Unmarshal(source, doc); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI document: %w", err)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("unsupported OpenAPI version '%s', only 3.x documents are supported", doc.OpenAPI)
+	}
+	return doc, nil
+}
+
+// findOperation looks up the operation with the given ID, resolving the parameters and request body it references.
+func (d *openAPIDocument) findOperation(operationID string) (*openAPIOperationRef, error) {
+	for path, item := range d.Paths {
+		for method, operation := range item.operations() {
+			if operation == nil || operation.OperationID != operationID {
+				continue
+			}
+			ref := &openAPIOperationRef{Method: method, Path: path, Servers: d.Servers}
+			if len(item.Servers) > 0 {
+				ref.Servers = item.Servers
+			}
+			if len(operation.Servers) > 0 {
+				ref.Servers = operation.Servers
+			}
+			var err error
+			if ref.Parameters, err = d.mergeParameters(item.Parameters, operation.Parameters); err != nil {
+				return nil, err
+			}
+			if ref.RequestBody, err = d.resolveRequestBody(operation.RequestBody); err != nil {
+				return nil, err
+			}
+			return ref, nil
+		}
+	}
+	return nil, fmt.Errorf("operation '%s' not found in the OpenAPI document", operationID)
+}
+
+// mergeParameters combines the path item parameters with the operation ones, the latter overriding the former
+// when they share the same name and location.
+func (d *openAPIDocument) mergeParameters(pathParams, operationParams []openAPIParameter) ([]openAPIParameter, error) {
+	var merged []openAPIParameter
+	index := map[string]int{}
+	for _, param := range append(append([]openAPIParameter{}, pathParams...), operationParams...) {
+		resolved, err := d.resolveParameter(param)
+		if err != nil {
+			return nil, err
+		}
+		key := resolved.In + "/" + resolved.Name
+		if i, ok := index[key]; ok {
+			merged[i] = resolved
+			continue
+		}
+		index[key] = len(merged)
+		merged = append(merged, resolved)
+	}
+	return merged, nil
+}
+
+func (d *openAPIDocument) resolveParameter(param openAPIParameter) (openAPIParameter, error) {
+	if param.Ref == "" {
+		return param, nil
+	}
+	resolved, ok := d.Components.Parameters[strings.TrimPrefix(param.Ref, openAPIComponentParameters)]
+	if !strings.HasPrefix(param.Ref, openAPIComponentParameters) || !ok {
+		return param, fmt.Errorf("unresolvable parameter reference '%s'", param.Ref)
+	}
+	return resolved, nil
+}
+
+func (d *openAPIDocument) resolveRequestBody(body *openAPIRequestBody) (*openAPIRequestBody, error) {
+	if body == nil || body.Ref == "" {
+		return body, nil
+	}
+	resolved, ok := d.Components.RequestBodies[strings.TrimPrefix(body.Ref, openAPIComponentRequestBodies)]
+	if !strings.HasPrefix(body.Ref, openAPIComponentRequestBodies) || !ok {
+		return nil, fmt.Errorf("unresolvable request body reference '%s'", body.Ref)
+	}
+	return &resolved, nil
+}
+
+func (p *openAPIPathItem) operations() map[string]*openAPIOperation {
+	return map[string]*openAPIOperation{
+		"GET":     p.Get,
+		"PUT":     p.Put,
+		"POST":    p.Post,
+		"DELETE":  p.Delete,
+		"OPTIONS": p.Options,
+		"HEAD":    p.Head,
+		"PATCH":   p.Patch,
+		"TRACE":   p.Trace,
+	}
+}
+
+// serverURL returns the URL of the first server declared for the operation, with its variables set to their defaults.
+// Relative URLs, including the implicit `/` server, are resolved against the location of the document.
+func (o *openAPIOperationRef) serverURL(documentLocation *url.URL) (string, error) {
+	server := openAPIServer{URL: "/"}
+	if len(o.Servers) > 0 {
+		server = o.Servers[0]
+	}
+	raw := uriTemplateVarPattern.ReplaceAllStringFunc(server.URL, func(match string) string {
+		if variable, ok := server.Variables[match[1:len(match)-1]]; ok {
+			return variable.Default
+		}
+		return match
+	})
+	serverURL, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("invalid server URL '%s': %w", server.URL, err)
+	}
+	if !serverURL.IsAbs() {
+		if documentLocation == nil || (documentLocation.Scheme != "http" && documentLocation.Scheme != "https") {
+			return "", fmt.Errorf("relative server URL '%s' requires an OpenAPI document served over HTTP", server.URL)
+		}
+		serverURL = documentLocation.ResolveReference(serverURL)
+	}
+	return strings.TrimSuffix(serverURL.String(), "/"), nil
+}
@@ -0,0 +1,246 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/serverlessworkflow/sdk-go/v3/impl/ctx"
+)
+
+// ErrInstanceNotFound is returned by state stores asked for an instance they do not hold.
+var ErrInstanceNotFound = errors.New("workflow instance not found")
+
+// InstanceState is the checkpoint of a workflow instance: enough to resume it once the process running it stopped.
+type InstanceState struct {
+	InstanceID string `json:"instanceID"`
+	// Definition is the JSON definition of the workflow.
+	Definition json.RawMessage `json:"definition"`
+	Context    *ctx.Snapshot   `json:"context"`
+	// Position lists the frames the instance was in, from the workflow's `do` list down to the innermost list of
+	// tasks. The instance resumes from there.
+	Position []ExecutionFrame `json:"position,omitempty"`
+}
+
+// ExecutionFrame is the position of an instance in a list of tasks, a `for` loop or a `try` task: the index of the
+// task, iteration or block it runs, along with the input it runs with.
+type ExecutionFrame struct {
+	Index int         `json:"index"`
+	Input interface{} `json:"input,omitempty"`
+}
+
+// StateStore persists the checkpoints of workflow instances.
+type StateStore interface {
+	// Save stores the state, replacing the previous checkpoint of the instance.
+	Save(ctx context.Context, state *InstanceState) error
+	// Load returns the last checkpoint of the instance, an error wrapping ErrInstanceNotFound when there is none.
+	Load(ctx context.Context, instanceID string) (*InstanceState, error)
+	// List returns the identifiers of the stored instances, e.g. to resume the ones still running after a restart.
+	List(ctx context.Context) ([]string, error)
+	// Delete removes the checkpoint of the instance, if any.
+	Delete(ctx context.Context, instanceID string) error
+}
+
+var (
+	_ StateStore = &MemoryStateStore{}
+	_ StateStore = &FileStateStore{}
+)
+
+// MemoryStateStore keeps the checkpoints in memory, serialized like a durable store would.
+type MemoryStateStore struct {
+	mu     sync.Mutex
+	states map[string][]byte
+}
+
+func NewMemoryStateStore() *MemoryStateStore {
+	return &MemoryStateStore{states: map[string][]byte{}}
+}
+
+func (s *MemoryStateStore) Save(ctx context.Context, state *InstanceState) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal workflow instance %s: %w", state.InstanceID, err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.states[state.InstanceID] = data
+	return nil
+}
+
+func (s *MemoryStateStore) Load(ctx context.Context, instanceID string) (*InstanceState, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	data, ok := s.states[instanceID]
+	s.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrInstanceNotFound, instanceID)
+	}
+	return unmarshalInstanceState(instanceID, data)
+}
+
+func (s *MemoryStateStore) List(ctx context.Context) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]string, 0, len(s.states))
+	for id := range s.states {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func (s *MemoryStateStore) Delete(ctx context.Context, instanceID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.states, instanceID)
+	return nil
+}
+
+const stateFileExtension = ".json"
+
+// FileStateStore keeps every checkpoint in a JSON file of the directory, named after the instance. Files are
+// replaced atomically, so a crash while saving leaves the previous checkpoint in place.
+type FileStateStore struct {
+	dir string
+}
+
+// NewFileStateStore creates the store in the directory, creating the directory if needed.
+func NewFileStateStore(dir string) (*FileStateStore, error) {
+	if err := os.MkdirAll(filepath.Clean(dir), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create state directory: %w", err)
+	}
+	return &FileStateStore{dir: filepath.Clean(dir)}, nil
+}
+
+func (s *FileStateStore) Save(ctx context.Context, state *InstanceState) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	path, err := s.path(state.InstanceID)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal workflow instance %s: %w", state.InstanceID, err)
+	}
+
+	file, err := os.CreateTemp(s.dir, "."+state.InstanceID+"-*")
+	if err != nil {
+		return fmt.Errorf("failed to save workflow instance %s: %w", state.InstanceID, err)
+	}
+	defer func() {
+		_ = os.Remove(file.Name())
+	}()
+	if _, err = file.Write(data); err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save workflow instance %s: %w", state.InstanceID, err)
+	}
+	return nil
+}
+
+func (s *FileStateStore) Load(ctx context.Context, instanceID string) (*InstanceState, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	path, err := s.path(instanceID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrInstanceNotFound, instanceID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load workflow instance %s: %w", instanceID, err)
+	}
+	return unmarshalInstanceState(instanceID, data)
+}
+
+func (s *FileStateStore) List(ctx context.Context) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workflow instances: %w", err)
+	}
+	var ids []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") || !strings.HasSuffix(name, stateFileExtension) {
+			continue
+		}
+		ids = append(ids, strings.TrimSuffix(name, stateFileExtension))
+	}
+	return ids, nil
+}
+
+func (s *FileStateStore) Delete(ctx context.Context, instanceID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	path, err := s.path(instanceID)
+	if err != nil {
+		return err
+	}
+	if err = os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete workflow instance %s: %w", instanceID, err)
+	}
+	return nil
+}
+
+// path returns the file of the instance, rejecting identifiers that would escape the directory.
+func (s *FileStateStore) path(instanceID string) (string, error) {
+	if instanceID == "" || strings.HasPrefix(instanceID, ".") || strings.ContainsAny(instanceID, `/\`) {
+		return "", fmt.Errorf("invalid workflow instance identifier '%s'", instanceID)
+	}
+	return filepath.Join(s.dir, instanceID+stateFileExtension), nil
+}
+
+func unmarshalInstanceState(instanceID string, data []byte) (*InstanceState, error) {
+	state := &InstanceState{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("invalid state of workflow instance %s: %w", instanceID, err)
+	}
+	return state, nil
+}
@@ -0,0 +1,295 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v3/impl/ctx"
+	"github.com/serverlessworkflow/sdk-go/v3/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+// crashingStore stops persisting checkpoints once crashed, like a process that stopped.
+type crashingStore struct {
+	StateStore
+	crashed atomic.Bool
+}
+
+func (s *crashingStore) Save(ctx context.Context, state *InstanceState) error {
+	if s.crashed.Load() {
+		return nil
+	}
+	return s.StateStore.Save(ctx, state)
+}
+
+// crashServer answers the calls of the workflows, counting them by path. The first call to the crash path stops
+// persisting checkpoints and hangs until the instance is cancelled, as if the process died there.
+type crashServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	calls    map[string]int
+	recorded []interface{}
+}
+
+func newCrashServer(t *testing.T, store *crashingStore, crashPath string, crashed chan<- struct{}) *crashServer {
+	server := &crashServer{calls: map[string]int{}}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.mu.Lock()
+		server.calls[r.URL.Path]++
+		first := server.calls[r.URL.Path] == 1
+		server.mu.Unlock()
+		if r.URL.Path == crashPath && first {
+			store.crashed.Store(true)
+			close(crashed)
+			<-r.Context().Done()
+			return
+		}
+
+		server.mu.Lock()
+		if item, ok := strings.CutPrefix(r.URL.Path, "/record/"); ok {
+			server.recorded = append(server.recorded, item)
+		}
+		body := `{"path": "` + r.URL.Path + `", "recorded": ["` + strings.Join(toStrings(server.recorded), `", "`) + `"]}`
+		server.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func (s *crashServer) callsTo(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[path]
+}
+
+func toStrings(values []interface{}) []string {
+	texts := make([]string, len(values))
+	for i, value := range values {
+		texts[i] = value.(string)
+	}
+	return texts
+}
+
+// crashAndResume runs the workflow until it crashes, then resumes it from the checkpoints left in the store.
+func crashAndResume(t *testing.T, source string, store StateStore, crashPath string) (*crashServer, interface{}, error) {
+	workflow, err := parser.FromYAMLSource([]byte(source))
+	assert.NoError(t, err)
+	crashing := &crashingStore{StateStore: store}
+	crashed := make(chan struct{})
+	server := newCrashServer(t, crashing, crashPath, crashed)
+
+	runner, err := NewDefaultRunner(workflow, WithStateStore(crashing))
+	assert.NoError(t, err)
+	handle, err := runner.Start(context.Background(), map[string]interface{}{"baseUrl": server.URL})
+	assert.NoError(t, err)
+	<-crashed
+	assert.NoError(t, handle.Cancel())
+	<-handle.Done()
+
+	resumed, err := ResumeInstance(context.Background(), store, handle.InstanceID())
+	assert.NoError(t, err)
+	assert.Equal(t, handle.InstanceID(), resumed.InstanceID())
+	output, err := resumed.Wait(context.Background())
+
+	state, loadErr := store.Load(context.Background(), handle.InstanceID())
+	assert.NoError(t, loadErr)
+	assert.Equal(t, ctx.CompletedStatus, state.Context.Status())
+	_, resumeErr := ResumeInstance(context.Background(), store, handle.InstanceID())
+	assert.ErrorIs(t, resumeErr, ErrInstanceNotRunning)
+	return server, output, err
+}
+
+func TestResumeInstance(t *testing.T) {
+	t.Run("inside for loops and try blocks", func(t *testing.T) {
+		server, output, err := crashAndResume(t, `
+document:
+  dsl: '1.0.0'
+  namespace: test
+  name: resume-for
+  version: '1.0.0'
+do:
+  - setup:
+      set:
+        baseUrl: '${ .baseUrl }'
+      export:
+        as: '${ { baseUrl: .baseUrl } }'
+  - init:
+      call: http
+      with:
+        method: get
+        endpoint: '${ $context.baseUrl + "/init" }'
+  - loop:
+      for:
+        each: item
+        in: '${ [1, 2, 3] }'
+      do:
+        - guarded:
+            try:
+              - record:
+                  call: http
+                  with:
+                    method: get
+                    endpoint: '${ $context.baseUrl + "/record/" + ($item | tostring) }'
+            catch:
+              errors:
+                with:
+                  status: 503
+`, NewMemoryStateStore(), "/record/2")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"path": "/record/3", "recorded": []interface{}{"1", "2", "3"}}, output)
+		assert.Equal(t, 1, server.callsTo("/init"))
+		assert.Equal(t, 1, server.callsTo("/record/1"))
+		assert.Equal(t, 2, server.callsTo("/record/2"))
+	})
+
+	t.Run("inside catch blocks", func(t *testing.T) {
+		store, err := NewFileStateStore(t.TempDir())
+		assert.NoError(t, err)
+		server, output, err := crashAndResume(t, `
+document:
+  dsl: '1.0.0'
+  namespace: test
+  name: resume-catch
+  version: '1.0.0'
+do:
+  - setup:
+      set:
+        baseUrl: '${ .baseUrl }'
+      export:
+        as: '${ { baseUrl: .baseUrl } }'
+  - guarded:
+      try:
+        - fail:
+            raise:
+              error:
+                type: https://serverlessworkflow.io/spec/1.0.0/errors/communication
+                status: 500
+                title: Unavailable
+                detail: The service is unavailable
+      catch:
+        as: caught
+        do:
+          - first:
+              call: http
+              with:
+                method: get
+                endpoint: '${ $context.baseUrl + "/first" }'
+          - second:
+              call: http
+              with:
+                method: get
+                endpoint: '${ $context.baseUrl + "/second/" + ($caught.status | tostring) }'
+`, store, "/second/500")
+		assert.NoError(t, err)
+		assert.Equal(t, "/second/500", output.(map[string]interface{})["path"])
+		assert.Equal(t, 1, server.callsTo("/first"))
+		assert.Equal(t, 2, server.callsTo("/second/500"))
+	})
+
+	t.Run("inside extensions", func(t *testing.T) {
+		server, output, err := crashAndResume(t, `
+document:
+  dsl: '1.0.0'
+  namespace: test
+  name: resume-extension
+  version: '1.0.0'
+use:
+  extensions:
+    - audit:
+        extend: call
+        before:
+          - auditBefore:
+              call: http
+              with:
+                method: get
+                endpoint: '${ $context.baseUrl + "/audit/before" }'
+        after:
+          - auditFirst:
+              call: http
+              with:
+                method: get
+                endpoint: '${ $context.baseUrl + "/audit/first" }'
+          - auditSecond:
+              call: http
+              with:
+                method: get
+                endpoint: '${ $context.baseUrl + "/audit/second" }'
+do:
+  - setup:
+      set:
+        baseUrl: '${ .baseUrl }'
+      export:
+        as: '${ { baseUrl: .baseUrl } }'
+  - work:
+      call: http
+      with:
+        method: get
+        endpoint: '${ $context.baseUrl + "/work" }'
+`, NewMemoryStateStore(), "/audit/second")
+		assert.NoError(t, err)
+		assert.Equal(t, "/work", output.(map[string]interface{})["path"])
+		// the extended task runs again, along with all its extensions
+		assert.Equal(t, 2, server.callsTo("/audit/before"))
+		assert.Equal(t, 2, server.callsTo("/work"))
+		assert.Equal(t, 2, server.callsTo("/audit/first"))
+		assert.Equal(t, 2, server.callsTo("/audit/second"))
+	})
+}
+
+func TestStateStores(t *testing.T) {
+	fileStore, err := NewFileStateStore(t.TempDir())
+	assert.NoError(t, err)
+	stores := map[string]StateStore{"memory": NewMemoryStateStore(), "file": fileStore}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			background := context.Background()
+			state := &InstanceState{
+				InstanceID: "instance-1",
+				Definition: []byte(`{"document": {"name": "test"}}`),
+				Context:    &ctx.Snapshot{Input: map[string]interface{}{"id": float64(1)}},
+				Position:   []ExecutionFrame{{Index: 2, Input: "item"}},
+			}
+			assert.NoError(t, store.Save(background, state))
+			assert.NoError(t, store.Save(background, &InstanceState{InstanceID: "instance-2"}))
+
+			loaded, err := store.Load(background, "instance-1")
+			assert.NoError(t, err)
+			assert.Equal(t, state.Context, loaded.Context)
+			assert.Equal(t, state.Position, loaded.Position)
+			assert.JSONEq(t, string(state.Definition), string(loaded.Definition))
+
+			ids, err := store.List(background)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"instance-1", "instance-2"}, ids)
+
+			assert.NoError(t, store.Delete(background, "instance-1"))
+			assert.NoError(t, store.Delete(background, "instance-1"))
+			_, err = store.Load(background, "instance-1")
+			assert.ErrorIs(t, err, ErrInstanceNotFound)
+		})
+	}
+
+	assert.Error(t, fileStore.Save(context.Background(), &InstanceState{InstanceID: "../escape"}))
+}
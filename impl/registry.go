@@ -0,0 +1,98 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/serverlessworkflow/sdk-go/v3/parser"
+)
+
+// ErrWorkflowNotFound is returned by registries that do not hold the requested workflow.
+var ErrWorkflowNotFound = errors.New("workflow not found")
+
+// WorkflowRegistry resolves the workflows run by `run: workflow` tasks.
+type WorkflowRegistry interface {
+	Get(namespace, name, version string) (*model.Workflow, error)
+}
+
+var _ WorkflowRegistry = &MemoryWorkflowRegistry{}
+
+// MemoryWorkflowRegistry holds workflow definitions indexed by namespace, name and version.
+type MemoryWorkflowRegistry struct {
+	mu        sync.RWMutex
+	workflows map[string]*model.Workflow
+}
+
+func NewMemoryWorkflowRegistry(workflows ...*model.Workflow) *MemoryWorkflowRegistry {
+	registry := &MemoryWorkflowRegistry{workflows: map[string]*model.Workflow{}}
+	for _, workflow := range workflows {
+		registry.Register(workflow)
+	}
+	return registry
+}
+
+// NewDirectoryWorkflowRegistry loads every workflow definition, in YAML or JSON, found in the directory tree.
+func NewDirectoryWorkflowRegistry(dir string) (*MemoryWorkflowRegistry, error) {
+	registry := NewMemoryWorkflowRegistry()
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".yaml", ".yml", ".json":
+		default:
+			return nil
+		}
+		workflow, err := parser.FromFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to load workflow from %s: %w", path, err)
+		}
+		registry.Register(workflow)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
+
+// Register adds the workflow, replacing any with the same namespace, name and version.
+func (r *MemoryWorkflowRegistry) Register(workflow *model.Workflow) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	document := workflow.Document
+	r.workflows[registryKey(document.Namespace, document.Name, document.Version)] = workflow
+}
+
+func (r *MemoryWorkflowRegistry) Get(namespace, name, version string) (*model.Workflow, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	workflow, ok := r.workflows[registryKey(namespace, name, version)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrWorkflowNotFound, registryKey(namespace, name, version))
+	}
+	return workflow, nil
+}
+
+func registryKey(namespace, name, version string) string {
+	return namespace + "/" + name + ":" + version
+}
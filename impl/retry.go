@@ -0,0 +1,165 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+)
+
+// maxRetryDelay is the longest delay between attempts, which backoff strategies growing the delay saturate at.
+const maxRetryDelay = time.Duration(math.MaxInt64)
+
+// retryExecutor decides whether and when a `try` task that raised a caught error is attempted again,
+// following the `catch.retry` policy. It is stateful and must be created per task execution.
+type retryExecutor struct {
+	Policy    *model.RetryPolicy
+	TaskName  string
+	Clock     Clock
+	startedAt time.Time
+	attempts  int
+	// jitter returns a random duration in [from, to], replaced in tests to make delays predictable
+	jitter func(from, to time.Duration) time.Duration
+}
+
+// newRetryExecutor resolves a policy referenced by name from `use.retries`. A nil policy yields a nil executor.
+func newRetryExecutor(policy *model.RetryPolicy, workflow *model.Workflow, taskName string, clock Clock) (*retryExecutor, error) {
+	if policy == nil {
+		return nil, nil
+	}
+	if policy.Ref != "" {
+		var retries map[string]*model.RetryPolicy
+		if workflow != nil && workflow.Use != nil {
+			retries = workflow.Use.Retries
+		}
+		resolved := *policy
+		if err := resolved.ResolveReference(retries); err != nil {
+			return nil, model.NewErrConfiguration(err, taskName)
+		}
+		policy = &resolved
+	}
+	return &retryExecutor{
+		Policy:    policy,
+		TaskName:  taskName,
+		Clock:     clock,
+		startedAt: clock.Now(),
+		jitter:    randomJitter,
+	}, nil
+}
+
+// nextDelay is called after an attempt that started at attemptStartedAt failed with the error exposed in the
+// expression variables. It returns the delay before the next attempt, or false once the policy stops retrying.
+func (r *retryExecutor) nextDelay(attemptStartedAt time.Time, input interface{}, taskSupport TaskSupport) (time.Duration, bool, error) {
+	retry, err := evaluateWhenExceptWhen(r.Policy.When, r.Policy.ExceptWhen, input, taskSupport, r.TaskName)
+	if err != nil || !retry {
+		return 0, false, err
+	}
+
+	r.attempts++
+	now := r.Clock.Now()
+	if attempt := r.Policy.Limit.Attempt; attempt != nil {
+		if attempt.Count > 0 && r.attempts > attempt.Count {
+			return 0, false, nil
+		}
+		if attempt.Duration != nil {
+			maxAttempt, err := r.duration(attempt.Duration)
+			if err != nil {
+				return 0, false, err
+			}
+			if now.Sub(attemptStartedAt) > maxAttempt {
+				return 0, false, nil
+			}
+		}
+	}
+
+	delay, err := r.delay()
+	if err != nil {
+		return 0, false, err
+	}
+
+	if r.Policy.Limit.Duration != nil {
+		maxTotal, err := r.duration(r.Policy.Limit.Duration)
+		if err != nil {
+			return 0, false, err
+		}
+		if now.Sub(r.startedAt) > maxTotal-delay {
+			return 0, false, nil
+		}
+	}
+	return delay, true, nil
+}
+
+// delay computes the wait before the current attempt: the policy delay, grown by the backoff strategy, plus jitter.
+func (r *retryExecutor) delay() (time.Duration, error) {
+	delay, err := r.duration(r.Policy.Delay)
+	if err != nil {
+		return 0, err
+	}
+
+	if backoff := r.Policy.Backoff; backoff != nil {
+		switch {
+		case backoff.Linear != nil:
+			delay = scaleDelay(delay, int64(r.attempts))
+		case backoff.Exponential != nil && r.attempts > 63:
+			delay = scaleDelay(delay, math.MaxInt64)
+		case backoff.Exponential != nil:
+			delay = scaleDelay(delay, int64(1)<<(r.attempts-1))
+		}
+	}
+
+	if jitter := r.Policy.Jitter; jitter != nil {
+		from, err := r.duration(jitter.From)
+		if err != nil {
+			return 0, err
+		}
+		to, err := r.duration(jitter.To)
+		if err != nil {
+			return 0, err
+		}
+		if extra := r.jitter(from, to); extra > maxRetryDelay-delay {
+			delay = maxRetryDelay
+		} else {
+			delay += extra
+		}
+	}
+	return delay, nil
+}
+
+// scaleDelay multiplies the delay by the factor, saturating at maxRetryDelay rather than overflowing.
+func scaleDelay(delay time.Duration, factor int64) time.Duration {
+	if delay > 0 && factor > int64(maxRetryDelay/delay) {
+		return maxRetryDelay
+	}
+	return delay * time.Duration(factor)
+}
+
+func (r *retryExecutor) duration(duration *model.Duration) (time.Duration, error) {
+	d, err := duration.AsTimeDuration()
+	if err != nil {
+		return 0, model.NewErrConfiguration(fmt.Errorf("invalid retry policy duration: %w", err), r.TaskName)
+	}
+	return d, nil
+}
+
+func randomJitter(from, to time.Duration) time.Duration {
+	if to <= from {
+		return from
+	}
+	return from + rand.N(to-from+1)
+}
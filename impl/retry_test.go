@@ -0,0 +1,246 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/serverlessworkflow/sdk-go/v3/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock advances its time by every requested wait instead of sleeping.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sleeps = append(c.sleeps, d)
+	c.now = c.now.Add(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newTestRetryExecutor(t *testing.T, policy *model.RetryPolicy, clock Clock) *retryExecutor {
+	executor, err := newRetryExecutor(policy, nil, "retry", clock)
+	assert.NoError(t, err)
+	executor.jitter = func(from, to time.Duration) time.Duration { return to }
+	return executor
+}
+
+// delays collects the delays of the retry executor until it stops retrying.
+func delays(t *testing.T, executor *retryExecutor, clock *fakeClock) []time.Duration {
+	var result []time.Duration
+	for i := 0; i < 10; i++ {
+		delay, ok, err := executor.nextDelay(clock.Now(), nil, newTaskSupport())
+		assert.NoError(t, err)
+		if !ok {
+			return result
+		}
+		clock.advance(delay)
+		result = append(result, delay)
+	}
+	t.Fatal("retry executor never stopped")
+	return nil
+}
+
+func TestRetryExecutor_Backoff(t *testing.T) {
+	second := &model.Duration{Value: model.DurationInline{Seconds: 1}}
+	limit := model.RetryLimit{Attempt: &model.RetryLimitAttempt{Count: 4}}
+
+	t.Run("constant", func(t *testing.T) {
+		clock := newFakeClock()
+		executor := newTestRetryExecutor(t, &model.RetryPolicy{Delay: second, Limit: limit}, clock)
+		assert.Equal(t, []time.Duration{time.Second, time.Second, time.Second, time.Second}, delays(t, executor, clock))
+	})
+
+	t.Run("linear", func(t *testing.T) {
+		clock := newFakeClock()
+		executor := newTestRetryExecutor(t, &model.RetryPolicy{
+			Delay: second, Limit: limit, Backoff: &model.RetryBackoff{Linear: &model.BackoffDefinition{}},
+		}, clock)
+		assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 4 * time.Second}, delays(t, executor, clock))
+	})
+
+	t.Run("exponential with jitter", func(t *testing.T) {
+		clock := newFakeClock()
+		executor := newTestRetryExecutor(t, &model.RetryPolicy{
+			Delay:   second,
+			Limit:   limit,
+			Backoff: &model.RetryBackoff{Exponential: &model.BackoffDefinition{}},
+			Jitter:  &model.RetryPolicyJitter{From: model.NewDurationExpr("PT0S"), To: model.NewDurationExpr("PT0.5S")},
+		}, clock)
+		half := 500 * time.Millisecond
+		assert.Equal(t, []time.Duration{time.Second + half, 2*time.Second + half, 4*time.Second + half, 8*time.Second + half}, delays(t, executor, clock))
+	})
+
+	t.Run("growing delays saturate", func(t *testing.T) {
+		hour := &model.Duration{Value: model.DurationInline{Hours: 1}}
+		jitter := &model.RetryPolicyJitter{From: model.NewDurationExpr("PT0S"), To: model.NewDurationExpr("PT1S")}
+		for _, backoff := range []*model.RetryBackoff{
+			{Exponential: &model.BackoffDefinition{}},
+			{Linear: &model.BackoffDefinition{}},
+		} {
+			for _, attempts := range []int{40, 100, math.MaxInt - 1} {
+				clock := newFakeClock()
+				executor := newTestRetryExecutor(t, &model.RetryPolicy{
+					Delay:   hour,
+					Backoff: backoff,
+					Jitter:  jitter,
+					Limit:   model.RetryLimit{Attempt: &model.RetryLimitAttempt{Count: math.MaxInt}},
+				}, clock)
+				executor.attempts = attempts
+				delay, ok, err := executor.nextDelay(clock.Now(), nil, newTaskSupport())
+				assert.NoError(t, err)
+				assert.True(t, ok)
+				if backoff.Exponential != nil || attempts > 100 {
+					assert.Equal(t, maxRetryDelay, delay)
+				} else {
+					assert.Equal(t, time.Duration(attempts+1)*time.Hour+time.Second, delay)
+				}
+			}
+		}
+
+		clock := newFakeClock()
+		executor := newTestRetryExecutor(t, &model.RetryPolicy{
+			Delay:   hour,
+			Backoff: &model.RetryBackoff{Exponential: &model.BackoffDefinition{}},
+			Limit:   model.RetryLimit{Duration: model.NewDurationExpr("P1D")},
+		}, clock)
+		executor.attempts = 100
+		_, ok, err := executor.nextDelay(clock.Now(), nil, newTaskSupport())
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
+
+func TestRetryExecutor_Limits(t *testing.T) {
+	second := &model.Duration{Value: model.DurationInline{Seconds: 1}}
+
+	t.Run("total duration", func(t *testing.T) {
+		clock := newFakeClock()
+		executor := newTestRetryExecutor(t, &model.RetryPolicy{
+			Delay: second,
+			Limit: model.RetryLimit{Duration: model.NewDurationExpr("PT3S")},
+		}, clock)
+		assert.Len(t, delays(t, executor, clock), 3)
+	})
+
+	t.Run("attempt duration", func(t *testing.T) {
+		clock := newFakeClock()
+		executor := newTestRetryExecutor(t, &model.RetryPolicy{
+			Delay: second,
+			Limit: model.RetryLimit{Attempt: &model.RetryLimitAttempt{Duration: model.NewDurationExpr("PT2S")}},
+		}, clock)
+		attemptStartedAt := clock.Now()
+		_, ok, err := executor.nextDelay(attemptStartedAt, nil, newTaskSupport())
+		assert.NoError(t, err)
+		assert.True(t, ok)
+
+		clock.advance(3 * time.Second)
+		_, ok, err = executor.nextDelay(attemptStartedAt, nil, newTaskSupport())
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("when and exceptWhen", func(t *testing.T) {
+		clock := newFakeClock()
+		executor := newTestRetryExecutor(t, &model.RetryPolicy{
+			When:       model.NewExpr("${ .retryable }"),
+			ExceptWhen: model.NewExpr("${ .fatal }"),
+		}, clock)
+		_, ok, err := executor.nextDelay(clock.Now(), map[string]interface{}{"retryable": true, "fatal": false}, newTaskSupport())
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		_, ok, err = executor.nextDelay(clock.Now(), map[string]interface{}{"retryable": true, "fatal": true}, newTaskSupport())
+		assert.NoError(t, err)
+		assert.False(t, ok)
+		_, ok, err = executor.nextDelay(clock.Now(), map[string]interface{}{"retryable": false}, newTaskSupport())
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("unknown reference", func(t *testing.T) {
+		_, err := newRetryExecutor(&model.RetryPolicy{Ref: "missing"}, &model.Workflow{}, "retry", newFakeClock())
+		assert.True(t, model.IsErrConfiguration(err))
+	})
+}
+
+func TestTryTaskRunner_Retry(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": 1}`))
+	}))
+	defer server.Close()
+
+	workflow, err := parser.FromFile("./testdata/try_catch_retry.yaml")
+	assert.NoError(t, err)
+
+	t.Run("succeeds after retrying with exponential backoff", func(t *testing.T) {
+		calls = 0
+		clock := newFakeClock()
+		runner, err := NewDefaultRunner(workflow, WithClock(clock))
+		assert.NoError(t, err)
+		output, err := runner.Run(map[string]interface{}{"baseUrl": server.URL})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"id": float64(1)}, output)
+		assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, clock.sleeps)
+	})
+
+	t.Run("last error propagates once attempts are exhausted", func(t *testing.T) {
+		calls = -10
+		clock := newFakeClock()
+		runner, err := NewDefaultRunner(workflow, WithClock(clock))
+		assert.NoError(t, err)
+		_, err = runner.Run(map[string]interface{}{"baseUrl": server.URL})
+		assert.True(t, model.IsErrCommunication(err))
+		assert.Equal(t, http.StatusServiceUnavailable, model.AsError(err).Status)
+		assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second}, clock.sleeps)
+		assert.Equal(t, -6, calls)
+	})
+}
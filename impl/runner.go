@@ -16,9 +16,13 @@ package impl
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"net/http"
 	"time"
 
+	"github.com/serverlessworkflow/sdk-go/v3/impl/auth"
+	"github.com/serverlessworkflow/sdk-go/v3/impl/events"
 	"github.com/serverlessworkflow/sdk-go/v3/impl/expr"
 	"github.com/serverlessworkflow/sdk-go/v3/impl/utils"
 
@@ -31,29 +35,269 @@ var _ TaskSupport = &workflowRunnerImpl{}
 
 // WorkflowRunner is the public API to run Workflows
 type WorkflowRunner interface {
+	WorkflowInstance
 	GetWorkflowDef() *model.Workflow
+	// Run executes the workflow synchronously. A runner runs a single instance: running or starting it again returns
+	// ErrInstanceStarted.
 	Run(input interface{}) (output interface{}, err error)
+	// RunWithContext executes the workflow synchronously, cancelled along with the context, be it the caller's or the
+	// runner's. Tasks see the caller's context through TaskSupport.GetContext, values included.
+	RunWithContext(ctx context.Context, input interface{}) (output interface{}, err error)
+	// Start runs the workflow in the background, returning right away the handle of the instance. The instance is
+	// cancelled along with the context: HTTP handlers starting instances that outlive the request pass a context that
+	// is never cancelled, e.g. context.WithoutCancel(r.Context()).
+	Start(ctx context.Context, input interface{}) (WorkflowHandle, error)
 	GetWorkflowCtx() ctx.WorkflowContext
 }
 
-func NewDefaultRunner(workflow *model.Workflow) (WorkflowRunner, error) {
+// RunnerOption configures optional collaborators of the default WorkflowRunner.
+type RunnerOption func(*workflowRunnerImpl)
+
+// WithHTTPClient sets the client used by `call: http` tasks. Defaults to http.DefaultClient.
+func WithHTTPClient(client *http.Client) RunnerOption {
+	return func(wr *workflowRunnerImpl) {
+		wr.HTTPClient = client
+	}
+}
+
+// WithTokenProvider sets the provider that acquires and caches OAuth2 and OpenID Connect tokens.
+// Defaults to a provider that talks to authorization servers through the runner's HTTP client.
+func WithTokenProvider(provider *auth.TokenProvider) RunnerOption {
+	return func(wr *workflowRunnerImpl) {
+		wr.TokenProvider = provider
+	}
+}
+
+// WithClock sets the time source used to wait, e.g. between retries. Defaults to the system clock.
+func WithClock(clock Clock) RunnerOption {
+	return func(wr *workflowRunnerImpl) {
+		wr.Clock = clock
+	}
+}
+
+// WithEventPublisher sets the publisher of the events emitted by `emit` tasks.
+func WithEventPublisher(publisher events.Publisher) RunnerOption {
+	return func(wr *workflowRunnerImpl) {
+		wr.EventPublisher = publisher
+	}
+}
+
+// WithEventSubscriber sets the source of the events consumed by `listen` tasks.
+func WithEventSubscriber(subscriber events.Subscriber) RunnerOption {
+	return func(wr *workflowRunnerImpl) {
+		wr.EventSubscriber = subscriber
+	}
+}
+
+// WithContainerRuntime sets the runtime of the containers run by `run: container` tasks.
+func WithContainerRuntime(runtime ContainerRuntime) RunnerOption {
+	return func(wr *workflowRunnerImpl) {
+		wr.ContainerRuntime = runtime
+	}
+}
+
+// WithWorkflowRegistry sets the registry resolving the workflows run by `run: workflow` tasks.
+func WithWorkflowRegistry(registry WorkflowRegistry) RunnerOption {
+	return func(wr *workflowRunnerImpl) {
+		wr.WorkflowRegistry = registry
+	}
+}
+
+// WithCatalogResolver sets the resolver loading the functions of catalogs. Defaults to a resolver loading functions
+// through the runner's HTTP client.
+func WithCatalogResolver(resolver *CatalogResolver) RunnerOption {
+	return func(wr *workflowRunnerImpl) {
+		wr.CatalogResolver = resolver
+	}
+}
+
+// WithGRPCTLSConfig sets the TLS configuration of the connections opened by `call: grpc` tasks. Without it, only
+// services listening on port 443 are reached over TLS, verified against the system roots, and calls carrying
+// credentials to other ports are refused.
+func WithGRPCTLSConfig(config *tls.Config) RunnerOption {
+	return func(wr *workflowRunnerImpl) {
+		wr.GRPCTLSConfig = config
+	}
+}
+
+// WithAsyncAPIBinding sets the binding carrying the messages of `call: asyncapi` tasks over the given protocol.
+func WithAsyncAPIBinding(protocol string, binding AsyncAPIBinding) RunnerOption {
+	return func(wr *workflowRunnerImpl) {
+		if wr.AsyncAPIBindings == nil {
+			wr.AsyncAPIBindings = map[string]AsyncAPIBinding{}
+		}
+		wr.AsyncAPIBindings[protocol] = binding
+	}
+}
+
+// WithSecretProvider sets the provider resolving the secrets declared in `use.secrets`.
+func WithSecretProvider(provider SecretProvider) RunnerOption {
+	return func(wr *workflowRunnerImpl) {
+		wr.SecretProvider = provider
+	}
+}
+
+// WithLifecycleEventPublisher sets the sink of the lifecycle CloudEvents published as the workflow and its tasks change
+// status, e.g. `io.serverlessworkflow.workflow.started.v1`. No lifecycle event is published without it.
+func WithLifecycleEventPublisher(publisher events.Publisher) RunnerOption {
+	return func(wr *workflowRunnerImpl) {
+		wr.LifecyclePublisher = publisher
+	}
+}
+
+// WithStateStore sets the store the instance is checkpointed to after every task, so that ResumeInstance continues it
+// once the process running it stopped. Instances run by `run: workflow` tasks are not checkpointed on their own.
+func WithStateStore(store StateStore) RunnerOption {
+	return func(wr *workflowRunnerImpl) {
+		wr.StateStore = store
+	}
+}
+
+func NewDefaultRunner(workflow *model.Workflow, opts ...RunnerOption) (WorkflowRunner, error) {
+	return NewDefaultRunnerWithContext(context.Background(), workflow, opts...)
+}
+
+// NewDefaultRunnerWithContext creates a runner whose instance is cancelled along with the context. The context is
+// the one tasks see through TaskSupport.GetContext, values included.
+func NewDefaultRunnerWithContext(parent context.Context, workflow *model.Workflow, opts ...RunnerOption) (WorkflowRunner, error) {
+	runner, err := newWorkflowRunner(parent, workflow, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return runner, nil
+}
+
+// newWorkflowRunner creates a runner whose instance is cancelled along with the parent context.
+func newWorkflowRunner(parent context.Context, workflow *model.Workflow, opts ...RunnerOption) (*workflowRunnerImpl, error) {
 	wfContext, err := ctx.NewWorkflowContext(workflow)
 	if err != nil {
 		return nil, err
 	}
+	return newWorkflowRunnerWithContext(parent, workflow, wfContext, opts...)
+}
+
+// newWorkflowRunnerWithContext creates the runner of the instance holding the workflow context, e.g. a restored one.
+func newWorkflowRunnerWithContext(parent context.Context, workflow *model.Workflow, wfContext ctx.WorkflowContext, opts ...RunnerOption) (*workflowRunnerImpl, error) {
 	// TODO: based on the workflow definition, the context might change.
-	objCtx := ctx.WithWorkflowContext(context.Background(), wfContext)
-	return &workflowRunnerImpl{
-		Workflow:  workflow,
-		Context:   objCtx,
-		RunnerCtx: wfContext,
-	}, nil
+	runCtx, cancel := context.WithCancel(parent)
+	objCtx := ctx.WithWorkflowContext(runCtx, wfContext)
+	runner := &workflowRunnerImpl{
+		Workflow:    workflow,
+		Context:     objCtx,
+		RunnerCtx:   wfContext,
+		parent:      parent,
+		instanceCtx: objCtx,
+	}
+	runner.control = newInstanceControl(cancel, runner.statusSetter(), nil)
+	for _, opt := range opts {
+		opt(runner)
+	}
+	if runner.TokenProvider == nil {
+		runner.TokenProvider = auth.NewTokenProvider(auth.WithTokenClient(runner.GetHTTPClient()))
+	}
+	if runner.CatalogResolver == nil {
+		runner.CatalogResolver = NewCatalogResolver(WithCatalogHTTPClient(runner.GetHTTPClient()))
+	}
+	if runner.StateStore != nil {
+		position, err := newExecutionStack(workflow)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		runner.position = position
+	}
+	return runner, nil
+}
+
+// runnerConfig holds the collaborators set by the options of the default runner, shared by the tasks it runs and the
+// nested instances it starts.
+type runnerConfig struct {
+	HTTPClient       *http.Client
+	TokenProvider    *auth.TokenProvider
+	Clock            Clock
+	EventPublisher   events.Publisher
+	EventSubscriber  events.Subscriber
+	ContainerRuntime ContainerRuntime
+	WorkflowRegistry WorkflowRegistry
+	CatalogResolver  *CatalogResolver
+	GRPCTLSConfig    *tls.Config
+	AsyncAPIBindings map[string]AsyncAPIBinding
+	SecretProvider   SecretProvider
+	// LifecyclePublisher is the sink of the lifecycle events, nil when they are not published.
+	LifecyclePublisher events.Publisher
+	// StateStore persists the checkpoints of the instance, nil when it is not persisted.
+	StateStore StateStore
+}
+
+// GetHTTPClient gets the client shared by tasks that talk to remote services over HTTP
+func (c *runnerConfig) GetHTTPClient() *http.Client {
+	if c.HTTPClient == nil {
+		return http.DefaultClient
+	}
+	return c.HTTPClient
+}
+
+// GetTokenProvider gets the provider caching OAuth2 and OpenID Connect tokens across tasks, nil when none is configured
+func (c *runnerConfig) GetTokenProvider() *auth.TokenProvider {
+	return c.TokenProvider
+}
+
+// GetClock gets the time source used by tasks that wait
+func (c *runnerConfig) GetClock() Clock {
+	if c.Clock == nil {
+		return systemClock{}
+	}
+	return c.Clock
+}
+
+// GetEventPublisher gets the publisher of the events emitted by the workflow, nil when none is configured
+func (c *runnerConfig) GetEventPublisher() events.Publisher {
+	return c.EventPublisher
+}
+
+// GetEventSubscriber gets the source of the events the workflow listens to, nil when none is configured
+func (c *runnerConfig) GetEventSubscriber() events.Subscriber {
+	return c.EventSubscriber
+}
+
+// GetContainerRuntime gets the runtime of the containers run by the workflow, nil when none is configured
+func (c *runnerConfig) GetContainerRuntime() ContainerRuntime {
+	return c.ContainerRuntime
+}
+
+// GetWorkflowRegistry gets the registry of the workflows run by the workflow, nil when none is configured
+func (c *runnerConfig) GetWorkflowRegistry() WorkflowRegistry {
+	return c.WorkflowRegistry
+}
+
+// GetCatalogResolver gets the resolver of the functions shared in catalogs, nil when none is configured
+func (c *runnerConfig) GetCatalogResolver() *CatalogResolver {
+	return c.CatalogResolver
+}
+
+// GetGRPCTLSConfig gets the TLS configuration of gRPC connections, nil when none is configured
+func (c *runnerConfig) GetGRPCTLSConfig() *tls.Config {
+	return c.GRPCTLSConfig
+}
+
+// GetAsyncAPIBinding gets the binding of the AsyncAPI protocol, nil when none is configured
+func (c *runnerConfig) GetAsyncAPIBinding(protocol string) AsyncAPIBinding {
+	return c.AsyncAPIBindings[protocol]
 }
 
 type workflowRunnerImpl struct {
+	runnerConfig
 	Workflow  *model.Workflow
 	Context   context.Context
 	RunnerCtx ctx.WorkflowContext
+	control   *instanceControl
+	// parent is the context the runner was created with, still in effect once a run is bound to the caller's one
+	parent context.Context
+	// instanceCtx is the context of the instance, free of the deadlines of its tasks
+	instanceCtx context.Context
+	// position tracks the frames the instance runs in, nil when its checkpoints are not persisted, e.g. in `fork`
+	// branches.
+	position *executionStack
 }
 
 func (wr *workflowRunnerImpl) CloneWithContext(newCtx context.Context) TaskSupport {
@@ -61,11 +305,57 @@ func (wr *workflowRunnerImpl) CloneWithContext(newCtx context.Context) TaskSuppo
 
 	ctxWithWf := ctx.WithWorkflowContext(newCtx, clonedWfCtx)
 
-	return &workflowRunnerImpl{
-		Workflow:  wr.Workflow,
-		Context:   ctxWithWf,
-		RunnerCtx: clonedWfCtx,
+	clone := *wr
+	clone.Context = ctxWithWf
+	clone.RunnerCtx = clonedWfCtx
+	// the position of concurrent clones, e.g. `fork` branches, is not tracked: they run again once resumed
+	clone.position = nil
+	return &clone
+}
+
+func (wr *workflowRunnerImpl) WithContext(newCtx context.Context) TaskSupport {
+	bound := *wr
+	bound.Context = newCtx
+	return &bound
+}
+
+func (wr *workflowRunnerImpl) config() *runnerConfig {
+	return &wr.runnerConfig
+}
+
+func (wr *workflowRunnerImpl) instanceContext() context.Context {
+	if wr.instanceCtx == nil {
+		return nil
+	}
+	return wr.control.detach(wr.instanceCtx)
+}
+
+func (wr *workflowRunnerImpl) GetSecret(name string) (interface{}, error) {
+	value, ok := wr.RunnerCtx.GetSecrets()[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrSecretNotDeclared, name)
 	}
+	return value, nil
+}
+
+func (wr *workflowRunnerImpl) NewSubWorkflowRunner(workflow *model.Workflow) (WorkflowRunner, error) {
+	wfContext, err := ctx.NewWorkflowContext(workflow)
+	if err != nil {
+		return nil, err
+	}
+	wfContext.SetParentInstanceID(wr.RunnerCtx.GetInstanceID())
+
+	runCtx, cancel := context.WithCancel(wr.Context)
+	child := *wr
+	child.Workflow = workflow
+	child.RunnerCtx = wfContext
+	child.Context = ctx.WithWorkflowContext(runCtx, wfContext)
+	child.instanceCtx = child.Context
+	child.parent = wr.Context
+	child.control = newInstanceControl(cancel, child.statusSetter(), wr.control)
+	child.StateStore = nil
+	child.position = nil
+	return &child, nil
 }
 
 func (wr *workflowRunnerImpl) RemoveLocalExprVars(keys ...string) {
@@ -122,7 +412,23 @@ func (wr *workflowRunnerImpl) GetWorkflowCtx() ctx.WorkflowContext {
 }
 
 func (wr *workflowRunnerImpl) SetTaskStatus(task string, status ctx.StatusPhase) {
-	wr.RunnerCtx.SetTaskStatus(task, status)
+	wr.setTaskStatus(task, status, lifecycleOutcome{})
+}
+
+func (wr *workflowRunnerImpl) SetTaskFaulted(task string, err error) {
+	if wr.isCancelled() {
+		wr.setTaskStatus(task, ctx.CancelledStatus, lifecycleOutcome{})
+		return
+	}
+	wr.setTaskStatus(task, ctx.FaultedStatus, lifecycleOutcome{err: err})
+}
+
+func (wr *workflowRunnerImpl) SetTaskCompleted(task string, output interface{}) {
+	wr.setTaskStatus(task, ctx.CompletedStatus, lifecycleOutcome{output: output})
+}
+
+func (wr *workflowRunnerImpl) SetTaskRetried(task string) {
+	wr.setTaskRetried(task)
 }
 
 func (wr *workflowRunnerImpl) GetWorkflowDef() *model.Workflow {
@@ -133,15 +439,34 @@ func (wr *workflowRunnerImpl) SetWorkflowInstanceCtx(value interface{}) {
 	wr.RunnerCtx.SetInstanceCtx(value)
 }
 
+func (wr *workflowRunnerImpl) RunWithContext(caller context.Context, input interface{}) (output interface{}, err error) {
+	if !wr.control.start() {
+		return nil, ErrInstanceStarted
+	}
+	wr.bindContext(caller)
+	return wr.run(input)
+}
+
 // Run executes the workflow synchronously.
 func (wr *workflowRunnerImpl) Run(input interface{}) (output interface{}, err error) {
+	if !wr.control.start() {
+		return nil, ErrInstanceStarted
+	}
+	return wr.run(input)
+}
+
+// run executes the workflow of the instance once started.
+func (wr *workflowRunnerImpl) run(input interface{}) (output interface{}, err error) {
+	defer wr.control.release()
 	defer func() {
 		if err != nil {
-			wr.RunnerCtx.SetStatus(ctx.FaultedStatus)
-			err = wr.wrapWorkflowError(err)
+			err = wr.fail(err)
 		}
 	}()
 
+	if err = wr.loadSecrets(); err != nil {
+		return nil, err
+	}
 	wr.RunnerCtx.SetRawInput(input)
 
 	// Process input
@@ -150,17 +475,54 @@ func (wr *workflowRunnerImpl) Run(input interface{}) (output interface{}, err er
 	}
 
 	wr.RunnerCtx.SetInput(input)
-	// Run tasks sequentially
-	wr.RunnerCtx.SetStatus(ctx.RunningStatus)
+	wr.setStatus(ctx.RunningStatus, lifecycleOutcome{})
+	wr.RunnerCtx.SetStartedAt(time.Now())
+	if err = wr.checkpoint(); err != nil {
+		return nil, err
+	}
+	return wr.runTasks()
+}
+
+// resume continues a restored instance from the position of its last checkpoint.
+func (wr *workflowRunnerImpl) resume() (output interface{}, err error) {
+	defer wr.control.release()
+	defer func() {
+		if err != nil {
+			err = wr.fail(err)
+		}
+	}()
+
+	if err = wr.loadSecrets(); err != nil {
+		return nil, err
+	}
+	wr.setStatus(ctx.RunningStatus, lifecycleOutcome{})
+	return wr.runTasks()
+}
+
+func (wr *workflowRunnerImpl) loadSecrets() error {
+	secrets, err := loadSecrets(wr.Context, wr.Workflow, wr.SecretProvider)
+	if err != nil {
+		return err
+	}
+	wr.RunnerCtx.SetSecrets(secrets)
+	return nil
+}
+
+// runTasks runs the tasks of the workflow sequentially, from the position being resumed if any.
+func (wr *workflowRunnerImpl) runTasks() (output interface{}, err error) {
 	doRunner, err := NewDoTaskRunner(wr.Workflow.Do)
 	if err != nil {
 		return nil, err
 	}
-	wr.RunnerCtx.SetStartedAt(time.Now())
-	output, err = doRunner.Run(wr.RunnerCtx.GetInput(), wr)
+	taskSupport, cancel, err := withTimeout(wr.Workflow.Timeout, wr, "/")
 	if err != nil {
 		return nil, err
 	}
+	defer cancel()
+	output, err = doRunner.Run(wr.RunnerCtx.GetInput(), taskSupport)
+	if err != nil {
+		return nil, timeoutErr(err, taskSupport.GetContext(), "/")
+	}
 
 	wr.RunnerCtx.ClearTaskContext()
 
@@ -170,22 +532,44 @@ func (wr *workflowRunnerImpl) Run(input interface{}) (output interface{}, err er
 	}
 
 	wr.RunnerCtx.SetOutput(output)
-	wr.RunnerCtx.SetStatus(ctx.CompletedStatus)
+	wr.setStatus(ctx.CompletedStatus, lifecycleOutcome{output: output})
+	wr.saveFinalState()
 	return output, nil
 }
 
+// fail reports the error ending the instance, moving it to the cancelled or faulted status.
+func (wr *workflowRunnerImpl) fail(err error) error {
+	if ctxErr := wr.Context.Err(); ctxErr != nil {
+		// however the cancellation surfaced, e.g. as a failed HTTP call, report it as such
+		err = newContextErr(ctxErr, wr.taskReference())
+	}
+	err = redactSecrets(wr.wrapWorkflowError(err), wr.RunnerCtx.GetSecrets())
+	if wr.isCancelled() {
+		wr.setStatus(ctx.CancelledStatus, lifecycleOutcome{})
+	} else {
+		wr.setStatus(ctx.FaultedStatus, lifecycleOutcome{err: err})
+	}
+	wr.saveFinalState()
+	return err
+}
+
 // wrapWorkflowError ensures workflow errors have a proper instance reference.
 func (wr *workflowRunnerImpl) wrapWorkflowError(err error) error {
-	taskReference := wr.RunnerCtx.GetTaskReference()
-	if len(taskReference) == 0 {
-		taskReference = "/"
-	}
+	taskReference := wr.taskReference()
 	if knownErr := model.AsError(err); knownErr != nil {
 		return knownErr.WithInstanceRef(wr.Workflow, taskReference)
 	}
 	return model.NewErrRuntime(fmt.Errorf("workflow '%s', task '%s': %w", wr.Workflow.Document.Name, taskReference, err), taskReference)
 }
 
+// taskReference returns the reference of the current task, the workflow's one when no task is running.
+func (wr *workflowRunnerImpl) taskReference() string {
+	if taskReference := wr.RunnerCtx.GetTaskReference(); len(taskReference) > 0 {
+		return taskReference
+	}
+	return "/"
+}
+
 // processInput validates and transforms input if needed.
 func (wr *workflowRunnerImpl) processInput(input interface{}) (output interface{}, err error) {
 	if wr.Workflow.Input != nil {
@@ -16,12 +16,18 @@ package impl
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/serverlessworkflow/sdk-go/v3/impl/ctx"
+	"github.com/serverlessworkflow/sdk-go/v3/impl/events"
 	"github.com/serverlessworkflow/sdk-go/v3/model"
 	"github.com/serverlessworkflow/sdk-go/v3/parser"
 	"github.com/stretchr/testify/assert"
@@ -41,6 +47,7 @@ func newTaskSupport(opts ...taskSupportOpts) TaskSupport {
 		Context:   context.TODO(),
 		RunnerCtx: wfCtx,
 	}
+	ts.control = newInstanceControl(func() {}, ts.statusSetter(), nil)
 
 	// Apply each functional option to ts
 	for _, opt := range opts {
@@ -70,6 +77,54 @@ func withRunnerCtx(workflowContext ctx.WorkflowContext) taskSupportOpts {
 	}
 }
 
+func withClock(clock Clock) taskSupportOpts {
+	return func(ts *workflowRunnerImpl) {
+		ts.Clock = clock
+	}
+}
+
+func withEventPublisher(publisher events.Publisher) taskSupportOpts {
+	return func(ts *workflowRunnerImpl) {
+		ts.EventPublisher = publisher
+	}
+}
+
+func withEventSubscriber(subscriber events.Subscriber) taskSupportOpts {
+	return func(ts *workflowRunnerImpl) {
+		ts.EventSubscriber = subscriber
+	}
+}
+
+func withContainerRuntime(runtime ContainerRuntime) taskSupportOpts {
+	return func(ts *workflowRunnerImpl) {
+		ts.ContainerRuntime = runtime
+	}
+}
+
+func withWorkflowRegistry(registry WorkflowRegistry) taskSupportOpts {
+	return func(ts *workflowRunnerImpl) {
+		ts.WorkflowRegistry = registry
+	}
+}
+
+func withCatalogResolver(resolver *CatalogResolver) taskSupportOpts {
+	return func(ts *workflowRunnerImpl) {
+		ts.CatalogResolver = resolver
+	}
+}
+
+func withGRPCTLSConfig(config *tls.Config) taskSupportOpts {
+	return func(ts *workflowRunnerImpl) {
+		ts.GRPCTLSConfig = config
+	}
+}
+
+func withAsyncAPIBinding(protocol string, binding AsyncAPIBinding) taskSupportOpts {
+	return func(ts *workflowRunnerImpl) {
+		WithAsyncAPIBinding(protocol, binding)(ts)
+	}
+}
+
 // runWorkflowTest is a reusable test function for workflows
 func runWorkflowTest(t *testing.T, workflowPath string, input, expectedOutput map[string]interface{}) {
 	// Run the workflow
@@ -467,3 +522,96 @@ func TestForkSimple_NoCompete(t *testing.T) {
 		runWorkflowTest(t, workflowPath, input, expectedOutput)
 	})
 }
+
+type contextKey string
+
+// roundTripperFunc answers the requests of an HTTP client without any network.
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestWorkflowRunner_RunWithContext(t *testing.T) {
+	waitWorkflow, err := parser.FromYAMLSource([]byte(`
+document:
+  dsl: '1.0.0'
+  namespace: test
+  name: wait
+  version: '1.0.0'
+do:
+  - pause:
+      wait: PT1H
+`))
+	assert.NoError(t, err)
+
+	t.Run("tasks see the caller's context values", func(t *testing.T) {
+		workflow, err := parser.FromYAMLSource([]byte(`
+document:
+  dsl: '1.0.0'
+  namespace: test
+  name: values
+  version: '1.0.0'
+do:
+  - getPet:
+      call: http
+      with:
+        method: get
+        endpoint: http://petstore.local/pets/1
+`))
+		assert.NoError(t, err)
+		var requestID interface{}
+		client := &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			requestID = req.Context().Value(contextKey("requestID"))
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(`{"id": 1}`)),
+				Request:    req,
+			}, nil
+		})}
+		runner, err := NewDefaultRunner(workflow, WithHTTPClient(client))
+		assert.NoError(t, err)
+
+		output, err := runner.RunWithContext(context.WithValue(context.Background(), contextKey("requestID"), "42"), nil)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"id": float64(1)}, output)
+		assert.Equal(t, "42", requestID)
+	})
+
+	t.Run("cancelled by the caller", func(t *testing.T) {
+		runner, err := NewDefaultRunner(waitWorkflow)
+		assert.NoError(t, err)
+		runCtx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(20*time.Millisecond, cancel)
+
+		_, err = runner.RunWithContext(runCtx, nil)
+		assert.True(t, model.IsErrRuntime(err))
+		assert.ErrorContains(t, err, "cancelled")
+		assert.Equal(t, "/do/0/pause", model.AsError(err).Instance.String())
+		assert.Equal(t, ctx.CancelledStatus, runner.GetWorkflowCtx().GetStatus())
+	})
+
+	t.Run("caller's deadline", func(t *testing.T) {
+		runner, err := NewDefaultRunner(waitWorkflow)
+		assert.NoError(t, err)
+		runCtx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		_, err = runner.RunWithContext(runCtx, nil)
+		assert.True(t, model.IsErrTimeout(err))
+		assert.Equal(t, ctx.FaultedStatus, runner.GetWorkflowCtx().GetStatus())
+	})
+
+	t.Run("cancelled along with the runner's context", func(t *testing.T) {
+		runnerCtx, cancel := context.WithCancel(context.Background())
+		runner, err := NewDefaultRunnerWithContext(runnerCtx, waitWorkflow)
+		assert.NoError(t, err)
+		time.AfterFunc(20*time.Millisecond, cancel)
+
+		_, err = runner.RunWithContext(context.Background(), nil)
+		assert.True(t, model.IsErrRuntime(err))
+		assert.ErrorContains(t, err, "cancelled")
+		assert.Equal(t, ctx.CancelledStatus, runner.GetWorkflowCtx().GetStatus())
+	})
+}
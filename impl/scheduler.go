@@ -0,0 +1,277 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v3/impl/events"
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+)
+
+// scheduleInstance is the instance reported by errors in workflow schedules.
+const scheduleInstance = "/schedule"
+
+// ScheduledRun reports the outcome of a workflow instance started by the Scheduler.
+type ScheduledRun struct {
+	Workflow  *model.Workflow
+	Input     interface{}
+	Output    interface{}
+	Err       error
+	StartedAt time.Time
+}
+
+// SchedulerOption configures optional collaborators of the Scheduler.
+type SchedulerOption func(*Scheduler)
+
+// WithSchedulerClock sets the time source the schedules are computed with. Defaults to the system clock.
+func WithSchedulerClock(clock Clock) SchedulerOption {
+	return func(s *Scheduler) {
+		s.clock = clock
+	}
+}
+
+// WithSchedulerEventSubscriber sets the source of the events starting the workflows scheduled `on` events.
+func WithSchedulerEventSubscriber(subscriber events.Subscriber) SchedulerOption {
+	return func(s *Scheduler) {
+		s.subscriber = subscriber
+	}
+}
+
+// WithSchedulerRunnerOptions sets the options of the runners created for every scheduled instance.
+func WithSchedulerRunnerOptions(opts ...RunnerOption) SchedulerOption {
+	return func(s *Scheduler) {
+		s.runnerOpts = append(s.runnerOpts, opts...)
+	}
+}
+
+// WithScheduledRunHandler sets the function notified when a scheduled instance ends. It may be called concurrently.
+func WithScheduledRunHandler(handler func(ScheduledRun)) SchedulerOption {
+	return func(s *Scheduler) {
+		s.handler = handler
+	}
+}
+
+// Scheduler starts workflow instances as declared by the workflows `schedule`:
+//   - every: an instance is started at every interval, whether or not the previous one completed;
+//   - cron: an instance is started at every activation of the cron expression;
+//   - after: an instance is started right away, then again after the delay once the previous one completed;
+//   - on: an instance is started every time the events satisfy the consumption strategy, with the consumed events as input.
+type Scheduler struct {
+	schedules  []*workflowSchedule
+	clock      Clock
+	subscriber events.Subscriber
+	runnerOpts []RunnerOption
+	handler    func(ScheduledRun)
+	running    sync.WaitGroup
+}
+
+type workflowSchedule struct {
+	workflow *model.Workflow
+	every    time.Duration
+	after    time.Duration
+	cron     *cronSchedule
+	on       *model.EventConsumptionStrategy
+}
+
+// NewScheduler validates the schedules of the workflows, every one of them must declare exactly one.
+func NewScheduler(workflows []*model.Workflow, opts ...SchedulerOption) (*Scheduler, error) {
+	scheduler := &Scheduler{clock: systemClock{}}
+	for _, opt := range opts {
+		opt(scheduler)
+	}
+	for _, workflow := range workflows {
+		schedule, err := newWorkflowSchedule(workflow)
+		if err != nil {
+			return nil, err
+		}
+		if schedule.on != nil && scheduler.subscriber == nil {
+			return nil, model.NewErrConfiguration(fmt.Errorf("workflow %s is scheduled on events but no event subscriber is configured", workflow.Document.Name), scheduleInstance)
+		}
+		scheduler.schedules = append(scheduler.schedules, schedule)
+	}
+	return scheduler, nil
+}
+
+func newWorkflowSchedule(workflow *model.Workflow) (*workflowSchedule, error) {
+	definition := workflow.Schedule
+	if definition == nil {
+		return nil, model.NewErrValidation(fmt.Errorf("workflow %s has no schedule", workflow.Document.Name), "/")
+	}
+	declared := 0
+	for _, set := range []bool{definition.Every != nil, definition.Cron != "", definition.After != nil, definition.On != nil} {
+		if set {
+			declared++
+		}
+	}
+	if declared != 1 {
+		return nil, model.NewErrValidation(fmt.Errorf("workflow %s schedule must declare exactly one of every, cron, after or on", workflow.Document.Name), scheduleInstance)
+	}
+
+	schedule := &workflowSchedule{workflow: workflow, on: definition.On}
+	var err error
+	switch {
+	case definition.Every != nil:
+		schedule.every, err = scheduleDuration(definition.Every, "every")
+	case definition.After != nil:
+		schedule.after, err = scheduleDuration(definition.After, "after")
+	case definition.Cron != "":
+		if schedule.cron, err = parseCron(definition.Cron); err != nil {
+			err = model.NewErrValidation(err, scheduleInstance+"/cron")
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+func scheduleDuration(duration *model.Duration, property string) (time.Duration, error) {
+	d, err := duration.AsTimeDuration()
+	if err != nil {
+		return 0, model.NewErrValidation(err, scheduleInstance+"/"+property)
+	}
+	if d <= 0 && property == "every" {
+		return 0, model.NewErrValidation(errors.New("the interval must be positive"), scheduleInstance+"/"+property)
+	}
+	return d, nil
+}
+
+// Run starts the scheduled instances until the context is done or a schedule fails. The running instances are
+// cancelled along with the context, and waited for before returning.
+func (s *Scheduler) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		schedules sync.WaitGroup
+		once      sync.Once
+		failure   error
+	)
+	for _, schedule := range s.schedules {
+		schedules.Add(1)
+		go func() {
+			defer schedules.Done()
+			if err := s.runSchedule(ctx, schedule); err != nil {
+				once.Do(func() {
+					failure = err
+					cancel()
+				})
+			}
+		}()
+	}
+	schedules.Wait()
+	s.running.Wait()
+	return failure
+}
+
+func (s *Scheduler) runSchedule(ctx context.Context, schedule *workflowSchedule) error {
+	switch {
+	case schedule.every > 0:
+		for sleep(ctx, s.clock, schedule.every) == nil {
+			s.start(ctx, schedule, nil)
+		}
+	case schedule.cron != nil:
+		for {
+			now := s.clock.Now()
+			next := schedule.cron.next(now)
+			if next.IsZero() {
+				return model.NewErrValidation(fmt.Errorf("cron expression '%s' never fires", schedule.workflow.Schedule.Cron), scheduleInstance+"/cron")
+			}
+			if sleep(ctx, s.clock, next.Sub(now)) != nil {
+				return nil
+			}
+			s.start(ctx, schedule, nil)
+		}
+	case schedule.on != nil:
+		return s.listen(ctx, schedule)
+	default:
+		for ctx.Err() == nil {
+			s.run(ctx, schedule, nil)
+			if sleep(ctx, s.clock, schedule.after) != nil {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// listen starts an instance every time the consumed events satisfy the strategy, then starts consuming again.
+func (s *Scheduler) listen(ctx context.Context, schedule *workflowSchedule) error {
+	support, err := newWorkflowRunner(ctx, schedule.workflow, s.runnerOpts...)
+	if err != nil {
+		return err
+	}
+	subscription, err := s.subscriber.Subscribe(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return model.NewErrCommunication(fmt.Errorf("failed to subscribe to events: %w", err), scheduleInstance+"/on")
+	}
+	defer subscription.Close()
+
+	consumer, err := newEventConsumer(schedule.on, nil, support, scheduleInstance+"/on")
+	if err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-subscription.Events():
+			if !ok {
+				return model.NewErrCommunication(events.ErrSubscriptionClosed, scheduleInstance+"/on")
+			}
+			done, err := consumer.offer(event, nil, support)
+			if err != nil {
+				return err
+			}
+			if !done {
+				continue
+			}
+			s.start(ctx, schedule, consumer.output())
+			if consumer, err = newEventConsumer(schedule.on, nil, support, scheduleInstance+"/on"); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// start runs an instance in the background.
+func (s *Scheduler) start(ctx context.Context, schedule *workflowSchedule, input interface{}) {
+	s.running.Add(1)
+	go func() {
+		defer s.running.Done()
+		s.run(ctx, schedule, input)
+	}()
+}
+
+func (s *Scheduler) run(ctx context.Context, schedule *workflowSchedule, input interface{}) {
+	result := ScheduledRun{Workflow: schedule.workflow, Input: input, StartedAt: s.clock.Now()}
+	runner, err := newWorkflowRunner(ctx, schedule.workflow, s.runnerOpts...)
+	if err == nil {
+		result.Output, result.Err = runner.Run(input)
+	} else {
+		result.Err = err
+	}
+	if s.handler != nil {
+		s.handler(result)
+	}
+}
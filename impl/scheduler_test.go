@@ -0,0 +1,191 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v3/impl/events"
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/serverlessworkflow/sdk-go/v3/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+// manualClock only lets waits elapse when the test advances it.
+type manualClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []manualTimer
+}
+
+type manualTimer struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func newManualClock() *manualClock {
+	return &manualClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *manualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *manualClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	c.timers = append(c.timers, manualTimer{at: c.now.Add(d), ch: ch})
+	return ch
+}
+
+// advance moves the time forward once a wait is pending, firing the elapsed ones.
+func (c *manualClock) advance(t *testing.T, d time.Duration) {
+	assert.Eventually(t, func() bool {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return len(c.timers) > 0
+	}, time.Second, time.Millisecond)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	pending := c.timers[:0]
+	for _, timer := range c.timers {
+		if timer.at.After(c.now) {
+			pending = append(pending, timer)
+			continue
+		}
+		timer.ch <- c.now
+	}
+	c.timers = pending
+}
+
+type schedulerTest struct {
+	runs   chan ScheduledRun
+	cancel context.CancelFunc
+	done   chan error
+}
+
+func startScheduler(t *testing.T, workflow *model.Workflow, opts ...SchedulerOption) *schedulerTest {
+	test := &schedulerTest{runs: make(chan ScheduledRun, 10), done: make(chan error, 1)}
+	opts = append(opts, WithScheduledRunHandler(func(run ScheduledRun) { test.runs <- run }))
+	scheduler, err := NewScheduler([]*model.Workflow{workflow}, opts...)
+	assert.NoError(t, err)
+
+	var ctx context.Context
+	ctx, test.cancel = context.WithCancel(context.Background())
+	go func() { test.done <- scheduler.Run(ctx) }()
+	t.Cleanup(func() {
+		test.cancel()
+		assert.NoError(t, <-test.done)
+	})
+	return test
+}
+
+func (s *schedulerTest) next(t *testing.T) ScheduledRun {
+	select {
+	case run := <-s.runs:
+		assert.NoError(t, run.Err)
+		return run
+	case <-time.After(time.Second):
+		t.Fatal("no scheduled run")
+		return ScheduledRun{}
+	}
+}
+
+func loadScheduledWorkflow(t *testing.T, schedule *model.Schedule) *model.Workflow {
+	workflow, err := parser.FromFile("./testdata/schedule_every.yaml")
+	assert.NoError(t, err)
+	if schedule != nil {
+		workflow.Schedule = schedule
+	}
+	return workflow
+}
+
+func TestScheduler_Every(t *testing.T) {
+	clock := newManualClock()
+	test := startScheduler(t, loadScheduledWorkflow(t, nil), WithSchedulerClock(clock))
+
+	clock.advance(t, 30*time.Second)
+	run := test.next(t)
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 30, 0, time.UTC), run.StartedAt)
+	assert.Equal(t, map[string]interface{}{"events": 0}, run.Output)
+
+	clock.advance(t, 30*time.Second)
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 1, 0, 0, time.UTC), test.next(t).StartedAt)
+}
+
+func TestScheduler_Cron(t *testing.T) {
+	clock := newManualClock()
+	clock.now = time.Date(2025, 1, 1, 0, 3, 0, 0, time.UTC)
+	test := startScheduler(t, loadScheduledWorkflow(t, &model.Schedule{Cron: "*/5 * * * *"}), WithSchedulerClock(clock))
+
+	clock.advance(t, 2*time.Minute)
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 5, 0, 0, time.UTC), test.next(t).StartedAt)
+	clock.advance(t, 5*time.Minute)
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 10, 0, 0, time.UTC), test.next(t).StartedAt)
+}
+
+func TestScheduler_After(t *testing.T) {
+	clock := newManualClock()
+	test := startScheduler(t, loadScheduledWorkflow(t, &model.Schedule{After: model.NewDurationExpr("PT1M")}), WithSchedulerClock(clock))
+
+	assert.Equal(t, clock.Now(), test.next(t).StartedAt)
+	clock.advance(t, time.Minute)
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 1, 0, 0, time.UTC), test.next(t).StartedAt)
+}
+
+func TestScheduler_On(t *testing.T) {
+	bus := events.NewMemoryBus()
+	workflow := loadScheduledWorkflow(t, &model.Schedule{On: &model.EventConsumptionStrategy{
+		All: []*model.EventFilter{filterOn("com.example.ping.v1"), filterOn("com.example.pong.v1")},
+	}})
+	test := startScheduler(t, workflow, WithSchedulerEventSubscriber(bus))
+	assert.Eventually(t, func() bool { return bus.Subscribers() > 0 }, time.Second, time.Millisecond)
+
+	for i := 0; i < 2; i++ {
+		publish(t, bus, "com.example.ping.v1", "/test", nil)
+		publish(t, bus, "com.example.pong.v1", "/test", nil)
+		run := test.next(t)
+		assert.Len(t, run.Input, 2)
+		assert.Equal(t, map[string]interface{}{"events": 2}, run.Output)
+	}
+}
+
+func TestNewScheduler_Invalid(t *testing.T) {
+	tests := map[string]*model.Schedule{
+		"no schedule":   {},
+		"two schedules": {Cron: "* * * * *", Every: model.NewDurationExpr("PT1M")},
+		"invalid cron":  {Cron: "* * *"},
+		"zero interval": {Every: model.NewDurationExpr("PT0S")},
+	}
+	for name, schedule := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewScheduler([]*model.Workflow{loadScheduledWorkflow(t, schedule)})
+			assert.True(t, model.IsErrValidation(err))
+		})
+	}
+
+	t.Run("events without subscriber", func(t *testing.T) {
+		workflow := loadScheduledWorkflow(t, &model.Schedule{On: &model.EventConsumptionStrategy{One: filterOn("com.example.ping.v1")}})
+		_, err := NewScheduler([]*model.Workflow{workflow})
+		assert.True(t, model.IsErrConfiguration(err))
+	})
+}
@@ -0,0 +1,251 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+)
+
+var (
+	// ErrSecretNotFound is returned by providers asked for a secret they do not hold.
+	ErrSecretNotFound = errors.New("secret not found")
+	// ErrSecretNotDeclared is returned when accessing a secret missing from the workflow's `use.secrets`.
+	ErrSecretNotDeclared = errors.New("secret not declared in 'use.secrets'")
+)
+
+// redactedSecret replaces the values of secrets found in error details.
+const redactedSecret = "***"
+
+// SecretProvider resolves the values of the secrets declared by workflows in `use.secrets`.
+type SecretProvider interface {
+	// GetSecret returns the value of the secret, either a string or an object, or an error wrapping
+	// ErrSecretNotFound when the provider does not hold it.
+	GetSecret(ctx context.Context, name string) (interface{}, error)
+}
+
+var (
+	_ SecretProvider = &EnvSecretProvider{}
+	_ SecretProvider = &FileSecretProvider{}
+	_ SecretProvider = &MemorySecretProvider{}
+)
+
+// EnvSecretProvider reads secrets from the environment variables named after them, with an optional prefix.
+// Variables holding a JSON object resolve to that object.
+type EnvSecretProvider struct {
+	prefix string
+}
+
+func NewEnvSecretProvider(prefix string) *EnvSecretProvider {
+	return &EnvSecretProvider{prefix: prefix}
+}
+
+func (p *EnvSecretProvider) GetSecret(_ context.Context, name string) (interface{}, error) {
+	value, ok := os.LookupEnv(p.prefix + name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+	}
+	return decodeSecret(value), nil
+}
+
+// FileSecretProvider reads secrets from a directory, as mounted e.g. by Kubernetes: a file named after the secret
+// holds its value, a directory named after it holds one file per property of the secret object.
+type FileSecretProvider struct {
+	dir string
+}
+
+func NewFileSecretProvider(dir string) *FileSecretProvider {
+	return &FileSecretProvider{dir: dir}
+}
+
+func (p *FileSecretProvider) GetSecret(_ context.Context, name string) (interface{}, error) {
+	if name == "" || name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		return nil, fmt.Errorf("%w: invalid secret name '%s'", ErrSecretNotFound, name)
+	}
+	path := filepath.Join(p.dir, name)
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return decodeSecret(strings.TrimRight(string(content), "\r\n")), nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	secret := map[string]interface{}{}
+	for _, entry := range entries {
+		// skip the hidden entries, such as the `..data` links of Kubernetes volumes
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(path, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		secret[entry.Name()] = strings.TrimRight(string(content), "\r\n")
+	}
+	return secret, nil
+}
+
+// MemorySecretProvider holds secrets in memory, e.g. for tests or secrets fetched by the embedding application.
+type MemorySecretProvider struct {
+	secrets map[string]interface{}
+}
+
+func NewMemorySecretProvider(secrets map[string]interface{}) *MemorySecretProvider {
+	return &MemorySecretProvider{secrets: secrets}
+}
+
+func (p *MemorySecretProvider) GetSecret(_ context.Context, name string) (interface{}, error) {
+	value, ok := p.secrets[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+	}
+	return value, nil
+}
+
+// decodeSecret returns the object held by a JSON encoded secret, or the secret itself.
+func decodeSecret(value string) interface{} {
+	if strings.HasPrefix(strings.TrimSpace(value), "{") {
+		var object map[string]interface{}
+		if err := json.Unmarshal([]byte(value), &object); err == nil {
+			return object
+		}
+	}
+	return value
+}
+
+// loadSecrets resolves the secrets declared by the workflow. Resolution errors never carry secret values.
+func loadSecrets(ctx context.Context, workflow *model.Workflow, provider SecretProvider) (map[string]interface{}, error) {
+	secrets := map[string]interface{}{}
+	if workflow == nil || workflow.Use == nil || len(workflow.Use.Secrets) == 0 {
+		return secrets, nil
+	}
+	if provider == nil {
+		return nil, model.NewErrConfiguration(fmt.Errorf("workflow declares secrets, but no secret provider is configured"), "/")
+	}
+	for _, name := range workflow.Use.Secrets {
+		value, err := provider.GetSecret(ctx, name)
+		if errors.Is(err, ErrSecretNotFound) {
+			return nil, model.NewErrConfiguration(fmt.Errorf("%w: %s", ErrSecretNotFound, name), "/")
+		}
+		if err != nil {
+			return nil, model.NewErrConfiguration(fmt.Errorf("failed to resolve secret %s", name), "/")
+		}
+		secrets[name] = value
+	}
+	return secrets, nil
+}
+
+// resolvePolicySecret returns a copy of the policy with the credentials it takes from a secret (`use`) filled in.
+// The secret holds the properties of the policy, e.g. `username` and `password` for a basic policy.
+func resolvePolicySecret(policy *model.AuthenticationPolicy, taskName string, taskSupport TaskSupport) (*model.AuthenticationPolicy, error) {
+	var name string
+	var target interface{}
+	resolved := *policy
+	switch {
+	case policy.Basic != nil && policy.Basic.Use != "":
+		name, resolved.Basic = policy.Basic.Use, &model.BasicAuthenticationPolicy{}
+		target = resolved.Basic
+	case policy.Bearer != nil && policy.Bearer.Use != "":
+		name, resolved.Bearer = policy.Bearer.Use, &model.BearerAuthenticationPolicy{}
+		target = resolved.Bearer
+	case policy.Digest != nil && policy.Digest.Use != "":
+		name, resolved.Digest = policy.Digest.Use, &model.DigestAuthenticationPolicy{}
+		target = resolved.Digest
+	case policy.OAuth2 != nil && policy.OAuth2.Use != "":
+		name, resolved.OAuth2 = policy.OAuth2.Use, &model.OAuth2AuthenticationPolicy{Properties: &model.OAuth2AuthenticationProperties{}}
+		target = resolved.OAuth2.Properties
+	case policy.OIDC != nil && policy.OIDC.Use != "":
+		name, resolved.OIDC = policy.OIDC.Use, &model.OpenIdConnectAuthenticationPolicy{Properties: &model.OAuth2AuthenticationProperties{}}
+		target = resolved.OIDC.Properties
+	default:
+		return policy, nil
+	}
+
+	value, err := taskSupport.GetSecret(name)
+	if err != nil {
+		return nil, model.NewErrConfiguration(err, taskName)
+	}
+	if token, ok := value.(string); ok && resolved.Bearer != nil {
+		value = map[string]interface{}{"token": token}
+	}
+	payload, err := json.Marshal(value)
+	if err == nil {
+		err = json.Unmarshal(payload, target)
+	}
+	if err != nil {
+		return nil, model.NewErrConfiguration(fmt.Errorf("secret %s does not hold valid credentials", name), taskName)
+	}
+	return &resolved, nil
+}
+
+// redactSecrets masks the values of the secrets found in the title and detail of the workflow error.
+func redactSecrets(err error, secrets map[string]interface{}) error {
+	values := secretStrings(secrets, nil)
+	workflowErr := model.AsError(err)
+	if workflowErr == nil || len(values) == 0 {
+		return err
+	}
+	redact := func(text *model.StringOrRuntimeExpr) *model.StringOrRuntimeExpr {
+		if text == nil {
+			return nil
+		}
+		redacted := text.String()
+		for _, value := range values {
+			redacted = strings.ReplaceAll(redacted, value, redactedSecret)
+		}
+		return model.NewStringOrRuntimeExpr(redacted)
+	}
+	workflowErr.Title = redact(workflowErr.Title)
+	workflowErr.Detail = redact(workflowErr.Detail)
+	return err
+}
+
+// secretStrings collects the string values held by the secrets.
+func secretStrings(value interface{}, collected []string) []string {
+	switch v := value.(type) {
+	case string:
+		if v != "" {
+			collected = append(collected, v)
+		}
+	case map[string]interface{}:
+		for _, item := range v {
+			collected = secretStrings(item, collected)
+		}
+	case []interface{}:
+		for _, item := range v {
+			collected = secretStrings(item, collected)
+		}
+	}
+	return collected
+}
@@ -0,0 +1,152 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/serverlessworkflow/sdk-go/v3/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecretProviders(t *testing.T) {
+	t.Run("environment", func(t *testing.T) {
+		t.Setenv("SW_SECRET_token", "abc")
+		t.Setenv("SW_SECRET_petStore", `{"username": "admin", "password": "s3cr3t"}`)
+		provider := NewEnvSecretProvider("SW_SECRET_")
+
+		value, err := provider.GetSecret(context.Background(), "token")
+		assert.NoError(t, err)
+		assert.Equal(t, "abc", value)
+		value, err = provider.GetSecret(context.Background(), "petStore")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"username": "admin", "password": "s3cr3t"}, value)
+		_, err = provider.GetSecret(context.Background(), "missing")
+		assert.ErrorIs(t, err, ErrSecretNotFound)
+	})
+
+	t.Run("directory", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "token"), []byte("abc\n"), 0o600))
+		assert.NoError(t, os.MkdirAll(filepath.Join(dir, "petStore", "..data"), 0o700))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "petStore", "username"), []byte("admin"), 0o600))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "petStore", "password"), []byte("s3cr3t\n"), 0o600))
+		provider := NewFileSecretProvider(dir)
+
+		value, err := provider.GetSecret(context.Background(), "token")
+		assert.NoError(t, err)
+		assert.Equal(t, "abc", value)
+		value, err = provider.GetSecret(context.Background(), "petStore")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"username": "admin", "password": "s3cr3t"}, value)
+		_, err = provider.GetSecret(context.Background(), "../token")
+		assert.ErrorIs(t, err, ErrSecretNotFound)
+	})
+}
+
+func TestSecrets_Workflow(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "admin" || password != "s3cr3t" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": 1}`))
+	}))
+	defer server.Close()
+
+	workflow, err := parser.FromFile("./testdata/secrets.yaml")
+	assert.NoError(t, err)
+	newProvider := func(apiKey string) SecretProvider {
+		return NewMemorySecretProvider(map[string]interface{}{
+			"petStore": map[string]interface{}{"username": "admin", "password": "s3cr3t"},
+			"apiKey":   apiKey,
+		})
+	}
+
+	t.Run("secrets authenticate calls and are exposed to expressions", func(t *testing.T) {
+		runner, err := NewDefaultRunner(workflow, WithSecretProvider(newProvider("k3y")))
+		assert.NoError(t, err)
+		output, err := runner.Run(map[string]interface{}{"baseUrl": server.URL})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"id": float64(1), "signed": true}, output)
+
+		vars := runner.GetWorkflowCtx().GetVars()
+		delete(vars, "$secrets")
+		descriptors, err := json.Marshal(vars)
+		assert.NoError(t, err)
+		assert.NotContains(t, string(descriptors), "s3cr3t")
+		assert.NotContains(t, string(descriptors), "admin")
+	})
+
+	t.Run("secret values are redacted from errors", func(t *testing.T) {
+		runner, err := NewDefaultRunner(workflow, WithSecretProvider(newProvider("leaked")))
+		assert.NoError(t, err)
+		_, err = runner.Run(map[string]interface{}{"baseUrl": server.URL})
+		assert.True(t, model.IsErrAuthorization(err))
+		assert.Equal(t, "key *** is not valid", model.AsError(err).Detail.String())
+	})
+
+	t.Run("declared secrets must be provided", func(t *testing.T) {
+		runner, err := NewDefaultRunner(workflow)
+		assert.NoError(t, err)
+		_, err = runner.Run(map[string]interface{}{"baseUrl": server.URL})
+		assert.True(t, model.IsErrConfiguration(err))
+
+		runner, err = NewDefaultRunner(workflow, WithSecretProvider(NewMemorySecretProvider(map[string]interface{}{"apiKey": "k3y"})))
+		assert.NoError(t, err)
+		_, err = runner.Run(map[string]interface{}{"baseUrl": server.URL})
+		assert.True(t, model.IsErrConfiguration(err))
+		assert.ErrorContains(t, err, "petStore")
+	})
+
+	t.Run("undeclared secrets are rejected", func(t *testing.T) {
+		_, err := parser.FromYAMLSource([]byte(`
+document:
+  dsl: '1.0.0'
+  namespace: test
+  name: undeclared-secret
+  version: '1.0.0'
+use:
+  secrets: [ declared ]
+do:
+  - read:
+      set:
+        value: '${ $secrets.undeclared }'
+`))
+		assert.ErrorContains(t, err, "secret_reference")
+
+		task := newCallHTTPTask("get", server.URL, func(args *model.HTTPArguments) {
+			args.Endpoint = &model.Endpoint{EndpointConfig: &model.EndpointConfiguration{
+				URI: &model.LiteralUri{Value: server.URL},
+				Authentication: &model.ReferenceableAuthenticationPolicy{
+					AuthenticationPolicy: &model.AuthenticationPolicy{Basic: &model.BasicAuthenticationPolicy{Use: "undeclared"}},
+				},
+			}}
+		})
+		runner, err := NewCallHttpRunner("get", task)
+		assert.NoError(t, err)
+		_, err = runner.Run(nil, newTaskSupport())
+		assert.True(t, model.IsErrConfiguration(err))
+		assert.ErrorContains(t, err, ErrSecretNotDeclared.Error())
+	})
+}
@@ -26,6 +26,16 @@ var _ TaskRunner = &SetTaskRunner{}
 var _ TaskRunner = &RaiseTaskRunner{}
 var _ TaskRunner = &ForTaskRunner{}
 var _ TaskRunner = &DoTaskRunner{}
+var _ TaskRunner = &CallHTTPTaskRunner{}
+var _ TaskRunner = &CallOpenAPITaskRunner{}
+var _ TaskRunner = &CallGRPCTaskRunner{}
+var _ TaskRunner = &CallAsyncAPITaskRunner{}
+var _ TaskRunner = &TryTaskRunner{}
+var _ TaskRunner = &WaitTaskRunner{}
+var _ TaskRunner = &EmitTaskRunner{}
+var _ TaskRunner = &ListenTaskRunner{}
+var _ TaskRunner = &RunTaskRunner{}
+var _ TaskRunner = &CallFunctionTaskRunner{}
 
 type TaskRunner interface {
 	Run(input interface{}, taskSupport TaskSupport) (interface{}, error)
@@ -33,7 +43,15 @@ type TaskRunner interface {
 }
 
 type TaskSupport interface {
+	// SetTaskStatus moves the task to the status, publishing the matching lifecycle events
 	SetTaskStatus(task string, status ctx.StatusPhase)
+	// SetTaskFaulted moves the task to the faulted status, the task faulted lifecycle event carrying the error, or to
+	// the cancelled status when the instance was cancelled
+	SetTaskFaulted(task string, err error)
+	// SetTaskCompleted moves the task to the completed status, the task completed lifecycle event carrying the output
+	SetTaskCompleted(task string, output interface{})
+	// SetTaskRetried publishes the task retried lifecycle event, the task running again as its retry policy requires
+	SetTaskRetried(task string)
 	GetWorkflowDef() *model.Workflow
 	// SetWorkflowInstanceCtx is the `$context` variable accessible in JQ expressions and set in `export.as`
 	SetWorkflowInstanceCtx(value interface{})
@@ -56,4 +74,43 @@ type TaskSupport interface {
 	// CloneWithContext returns a full clone of this TaskSupport, but using
 	// the provided context.Context (so deadlines/cancellations propagate).
 	CloneWithContext(ctx context.Context) TaskSupport
+	// WithContext returns a TaskSupport sharing this workflow context, but bound to the provided context.Context,
+	// e.g. to enforce a deadline on a single task.
+	WithContext(ctx context.Context) TaskSupport
+	// GetSecret gets the value of a secret declared in `use.secrets`, an error wrapping ErrSecretNotDeclared otherwise
+	GetSecret(name string) (interface{}, error)
+	// AwaitResume blocks while the instance is suspended, returning the context error if it is done meanwhile
+	AwaitResume() error
+	// NewSubWorkflowRunner creates the runner of a nested instance of the workflow, configured like this runner,
+	// correlated with this instance and cancelled along with this TaskSupport context.
+	NewSubWorkflowRunner(workflow *model.Workflow) (WorkflowRunner, error)
+}
+
+// configured is implemented by the TaskSupport of the default runner, which holds the collaborators set by its
+// options.
+type configured interface {
+	config() *runnerConfig
+}
+
+// configOf returns the collaborators of the runner running the tasks, none when the TaskSupport does not hold them.
+func configOf(taskSupport TaskSupport) *runnerConfig {
+	if c, ok := taskSupport.(configured); ok {
+		return c.config()
+	}
+	return &runnerConfig{}
+}
+
+// instanceScoped is implemented by the TaskSupport of the default runner, which knows the context of the instance
+// itself rather than the one of the current task.
+type instanceScoped interface {
+	instanceContext() context.Context
+}
+
+// detachedContext returns the context of the work outliving the task, e.g. processes and containers not awaited: it
+// is done when the instance is cancelled, not when the task or the workflow ends.
+func detachedContext(taskSupport TaskSupport) context.Context {
+	if scoped, ok := taskSupport.(instanceScoped); ok && scoped.instanceContext() != nil {
+		return scoped.instanceContext()
+	}
+	return context.WithoutCancel(taskSupport.GetContext())
 }
@@ -0,0 +1,232 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v3/impl/auth"
+	"github.com/serverlessworkflow/sdk-go/v3/impl/ctx"
+	"github.com/serverlessworkflow/sdk-go/v3/impl/expr"
+	"github.com/serverlessworkflow/sdk-go/v3/impl/utils"
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+)
+
+type CallAsyncAPITaskRunner struct {
+	Task     *model.CallAsyncAPI
+	TaskName string
+}
+
+func NewCallAsyncAPITaskRunner(taskName string, task *model.CallAsyncAPI) (*CallAsyncAPITaskRunner, error) {
+	if task == nil || task.With.Document == nil {
+		return nil, model.NewErrValidation(fmt.Errorf("invalid AsyncAPI call task %s", taskName), taskName)
+	}
+	if task.With.Operation == "" && task.With.Channel == "" {
+		return nil, model.NewErrValidation(fmt.Errorf("AsyncAPI call task %s must set either an operation or a channel", taskName), taskName)
+	}
+	return &CallAsyncAPITaskRunner{Task: task, TaskName: taskName}, nil
+}
+
+// Run resolves the operation from the AsyncAPI document and performs it through the binding of its protocol. A send
+// operation publishes the message and returns the task input; a receive operation consumes the messages, as
+// `{payload, headers}` objects, until the consumption policy is satisfied and returns them.
+func (a *CallAsyncAPITaskRunner) Run(input interface{}, taskSupport TaskSupport) (interface{}, error) {
+	args := a.Task.With
+	source, _, err := loadExternalResource(args.Document, input, a.TaskName, taskSupport)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := parseAsyncAPIDocument(source)
+	if err != nil {
+		return nil, model.NewErrConfiguration(err, a.TaskName)
+	}
+	var serverName string
+	if args.Server != nil {
+		serverName = args.Server.Name
+	}
+	operation, err := doc.findOperation(args.Operation, args.Channel, serverName, a.defaultAction())
+	if err != nil {
+		return nil, model.NewErrConfiguration(err, a.TaskName)
+	}
+
+	endpoint, err := a.evaluateEndpoint(operation, input, taskSupport)
+	if err != nil {
+		return nil, err
+	}
+	binding := configOf(taskSupport).GetAsyncAPIBinding(endpoint.Protocol)
+	if binding == nil {
+		return nil, model.NewErrConfiguration(fmt.Errorf("no AsyncAPI binding configured for protocol '%s'", endpoint.Protocol), a.TaskName)
+	}
+
+	if operation.Action == asyncAPIActionSend {
+		return a.publish(binding, endpoint, input, taskSupport)
+	}
+	return a.subscribe(binding, endpoint, input, taskSupport)
+}
+
+func (a *CallAsyncAPITaskRunner) GetTaskName() string {
+	return a.TaskName
+}
+
+// defaultAction infers the action on a channel called without an operation: sending when a message is set.
+func (a *CallAsyncAPITaskRunner) defaultAction() string {
+	if a.Task.With.Message != nil {
+		return asyncAPIActionSend
+	}
+	return asyncAPIActionReceive
+}
+
+func (a *CallAsyncAPITaskRunner) evaluateEndpoint(operation *asyncAPIOperationRef, input interface{}, taskSupport TaskSupport) (AsyncAPIEndpoint, error) {
+	args := a.Task.With
+	endpoint := AsyncAPIEndpoint{Protocol: args.Protocol, Channel: operation.Address}
+	if operation.Server != nil {
+		var variables map[string]interface{}
+		if args.Server != nil && len(args.Server.Variables) > 0 {
+			evaluated, err := expr.TraverseAndEvaluate(utils.DeepClone(args.Server.Variables), input, taskSupport.GetContext())
+			if err != nil {
+				return endpoint, model.NewErrExpression(err, a.TaskName)
+			}
+			variables = evaluated.(map[string]interface{})
+		}
+		endpoint.Host = operation.Server.substituteVariables(operation.Server.Host, variables)
+		endpoint.Pathname = operation.Server.substituteVariables(operation.Server.Pathname, variables)
+		if endpoint.Protocol == "" {
+			endpoint.Protocol = operation.Server.Protocol
+		}
+	}
+	if endpoint.Protocol == "" {
+		return endpoint, model.NewErrConfiguration(fmt.Errorf("no protocol set for the AsyncAPI operation of task %s", a.TaskName), a.TaskName)
+	}
+
+	policy, err := auth.ResolvePolicy(args.Authentication, taskSupport.GetWorkflowDef())
+	if err != nil {
+		return endpoint, model.NewErrConfiguration(err, a.TaskName)
+	}
+	if policy != nil {
+		if endpoint.Authentication, err = evaluateAuthenticationPolicy(policy, input, a.TaskName, taskSupport); err != nil {
+			return endpoint, err
+		}
+	}
+	return endpoint, nil
+}
+
+func (a *CallAsyncAPITaskRunner) publish(binding AsyncAPIBinding, endpoint AsyncAPIEndpoint, input interface{}, taskSupport TaskSupport) (interface{}, error) {
+	message := &AsyncAPIMessage{}
+	if outbound := a.Task.With.Message; outbound != nil {
+		if len(outbound.Payload) > 0 {
+			payload, err := expr.TraverseAndEvaluate(utils.DeepClone(outbound.Payload), input, taskSupport.GetContext())
+			if err != nil {
+				return nil, model.NewErrExpression(err, a.TaskName)
+			}
+			message.Payload = payload
+		}
+		if len(outbound.Headers) > 0 {
+			headers, err := expr.TraverseAndEvaluate(utils.DeepClone(outbound.Headers), input, taskSupport.GetContext())
+			if err != nil {
+				return nil, model.NewErrExpression(err, a.TaskName)
+			}
+			message.Headers = headers.(map[string]interface{})
+		}
+	}
+	if err := binding.Publish(taskSupport.GetContext(), endpoint, message); err != nil {
+		if ctxErr := taskSupport.GetContext().Err(); ctxErr != nil {
+			return nil, newContextErr(ctxErr, a.TaskName)
+		}
+		return nil, model.NewErrCommunication(fmt.Errorf("failed to publish to channel %s: %w", endpoint.Channel, err), a.TaskName)
+	}
+	return input, nil
+}
+
+// subscribe consumes the messages passing the subscription filter until the consumption policy is satisfied: the
+// amount of messages is reached, the `for` duration elapsed, the `while` condition no longer holds or the `until`
+// condition does. Both conditions are evaluated against each consumed message; the message satisfying `until` is
+// part of the output, the one breaking `while` is not.
+func (a *CallAsyncAPITaskRunner) subscribe(binding AsyncAPIBinding, endpoint AsyncAPIEndpoint, input interface{}, taskSupport TaskSupport) (interface{}, error) {
+	subscription := a.Task.With.Subscription
+	if subscription == nil || subscription.Consume == nil {
+		return nil, model.NewErrValidation(fmt.Errorf("receive operation of task %s requires a subscription with a consumption policy", a.TaskName), a.TaskName)
+	}
+	consume := subscription.Consume
+
+	var deadline <-chan time.Time
+	if consume.For != nil {
+		duration, err := consume.For.AsTimeDuration()
+		if err != nil {
+			return nil, model.NewErrConfiguration(fmt.Errorf("invalid consumption duration: %w", err), a.TaskName)
+		}
+		deadline = configOf(taskSupport).GetClock().After(duration)
+	}
+
+	stream, err := binding.Subscribe(taskSupport.GetContext(), endpoint)
+	if err != nil {
+		if ctxErr := taskSupport.GetContext().Err(); ctxErr != nil {
+			return nil, newContextErr(ctxErr, a.TaskName)
+		}
+		return nil, model.NewErrCommunication(fmt.Errorf("failed to subscribe to channel %s: %w", endpoint.Channel, err), a.TaskName)
+	}
+	defer stream.Close()
+
+	taskSupport.SetTaskStatus(a.TaskName, ctx.WaitingStatus)
+	defer taskSupport.SetTaskStatus(a.TaskName, ctx.RunningStatus)
+	consumed := []interface{}{}
+	for consume.Amount <= 0 || len(consumed) < consume.Amount {
+		select {
+		case <-taskSupport.GetContext().Done():
+			return nil, newContextErr(taskSupport.GetContext().Err(), a.TaskName)
+		case <-deadline:
+			return consumed, nil
+		case received, ok := <-stream.Messages():
+			if !ok {
+				return nil, model.NewErrCommunication(ErrAsyncAPISubscriptionClosed, a.TaskName)
+			}
+			message := received.toMap()
+			matches, err := a.evaluateCondition(subscription.Filter, message, true, taskSupport)
+			if err != nil {
+				return nil, err
+			}
+			if !matches {
+				continue
+			}
+			keepConsuming, err := a.evaluateCondition(consume.While, message, true, taskSupport)
+			if err != nil {
+				return nil, err
+			}
+			if !keepConsuming {
+				return consumed, nil
+			}
+			consumed = append(consumed, message)
+			done, err := a.evaluateCondition(consume.Until, message, false, taskSupport)
+			if err != nil {
+				return nil, err
+			}
+			if done {
+				return consumed, nil
+			}
+		}
+	}
+	return consumed, nil
+}
+
+func (a *CallAsyncAPITaskRunner) evaluateCondition(condition *model.RuntimeExpression, message map[string]interface{}, unset bool, taskSupport TaskSupport) (bool, error) {
+	if condition == nil {
+		return unset, nil
+	}
+	result, err := expr.TraverseAndEvaluateBool(model.NormalizeExpr(condition.String()), message, taskSupport.GetContext())
+	if err != nil {
+		return false, model.NewErrExpression(err, a.TaskName)
+	}
+	return result, nil
+}
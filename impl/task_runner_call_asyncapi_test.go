@@ -0,0 +1,182 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/serverlessworkflow/sdk-go/v3/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+var ordersEndpoint = AsyncAPIEndpoint{Protocol: "kafka", Host: "broker.eu.example.com", Channel: "orders.created"}
+
+func ordersDocumentURI(t *testing.T) string {
+	path, err := filepath.Abs("./testdata/asyncapi/orders.yaml")
+	assert.NoError(t, err)
+	return fileURI(path)
+}
+
+func newConsumeOrdersTask(t *testing.T, subscription *model.AsyncAPISubscription) *model.CallAsyncAPI {
+	return &model.CallAsyncAPI{
+		Call: "asyncapi",
+		With: model.AsyncAPIArguments{
+			Document:     &model.ExternalResource{Endpoint: model.NewEndpoint(ordersDocumentURI(t))},
+			Operation:    "consumeOrders",
+			Subscription: subscription,
+		},
+	}
+}
+
+type taskResult struct {
+	output interface{}
+	err    error
+}
+
+// startConsuming runs the task in the background and waits until it subscribed to the orders channel.
+func startConsuming(t *testing.T, runner TaskRunner, binding *MemoryAsyncAPIBinding, taskSupport TaskSupport) <-chan taskResult {
+	result := make(chan taskResult, 1)
+	go func() {
+		output, err := runner.Run(nil, taskSupport)
+		result <- taskResult{output: output, err: err}
+	}()
+	assert.Eventually(t, func() bool { return binding.Subscribers(ordersEndpoint) == 1 }, time.Second, time.Millisecond)
+	return result
+}
+
+func publishOrder(t *testing.T, binding *MemoryAsyncAPIBinding, id string, total float64) {
+	assert.NoError(t, binding.Publish(context.Background(), ordersEndpoint, &AsyncAPIMessage{
+		Payload: map[string]interface{}{"id": id, "total": total},
+	}))
+}
+
+func TestCallAsyncAPITaskRunner_Publish(t *testing.T) {
+	binding := NewMemoryAsyncAPIBinding()
+	subscription, err := binding.Subscribe(context.Background(), ordersEndpoint)
+	assert.NoError(t, err)
+	defer subscription.Close()
+
+	workflow, err := parser.FromFile("./testdata/call_asyncapi.yaml")
+	assert.NoError(t, err)
+	runner, err := NewDefaultRunner(workflow, WithAsyncAPIBinding("kafka", binding))
+	assert.NoError(t, err)
+	input := map[string]interface{}{"documentUri": ordersDocumentURI(t), "region": "eu", "order": map[string]interface{}{"id": "o-1", "total": 12.5}}
+	output, err := runner.Run(input)
+	assert.NoError(t, err)
+	assert.Equal(t, input, output)
+
+	message := <-subscription.Messages()
+	assert.Equal(t, map[string]interface{}{"id": "o-1", "total": 12.5}, message.Payload)
+	assert.Equal(t, map[string]interface{}{"source": "workflow"}, message.Headers)
+}
+
+func TestCallAsyncAPITaskRunner_Subscribe(t *testing.T) {
+	t.Run("filtered messages are consumed up to the amount", func(t *testing.T) {
+		binding := NewMemoryAsyncAPIBinding()
+		runner, err := NewCallAsyncAPITaskRunner("consume", newConsumeOrdersTask(t, &model.AsyncAPISubscription{
+			Filter:  model.NewExpr("${ .payload.total > 10 }"),
+			Consume: &model.AsyncAPIMessageConsumptionPolicy{Amount: 2},
+		}))
+		assert.NoError(t, err)
+		result := startConsuming(t, runner, binding, newTaskSupport(withAsyncAPIBinding("kafka", binding)))
+
+		publishOrder(t, binding, "o-1", 5)
+		publishOrder(t, binding, "o-2", 20)
+		publishOrder(t, binding, "o-3", 30)
+		r := <-result
+		assert.NoError(t, r.err)
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"payload": map[string]interface{}{"id": "o-2", "total": float64(20)}, "headers": map[string]interface{}{}},
+			map[string]interface{}{"payload": map[string]interface{}{"id": "o-3", "total": float64(30)}, "headers": map[string]interface{}{}},
+		}, r.output)
+	})
+
+	t.Run("until includes the last message, while excludes it", func(t *testing.T) {
+		for name, consume := range map[string]*model.AsyncAPIMessageConsumptionPolicy{
+			"until": {Until: model.NewExpr(`${ .payload.id == "o-2" }`)},
+			"while": {While: model.NewExpr(`${ .payload.id != "o-3" }`)},
+		} {
+			binding := NewMemoryAsyncAPIBinding()
+			runner, err := NewCallAsyncAPITaskRunner("consume", newConsumeOrdersTask(t, &model.AsyncAPISubscription{Consume: consume}))
+			assert.NoError(t, err)
+			result := startConsuming(t, runner, binding, newTaskSupport(withAsyncAPIBinding("kafka", binding)))
+
+			publishOrder(t, binding, "o-1", 1)
+			publishOrder(t, binding, "o-2", 2)
+			publishOrder(t, binding, "o-3", 3)
+			r := <-result
+			assert.NoError(t, r.err, name)
+			assert.Len(t, r.output, 2, name)
+		}
+	})
+
+	t.Run("consumption stops when the duration elapsed", func(t *testing.T) {
+		binding := NewMemoryAsyncAPIBinding()
+		clock := newManualClock()
+		runner, err := NewCallAsyncAPITaskRunner("consume", newConsumeOrdersTask(t, &model.AsyncAPISubscription{
+			Consume: &model.AsyncAPIMessageConsumptionPolicy{Amount: 5, For: model.NewDurationExpr("PT1M")},
+		}))
+		assert.NoError(t, err)
+		result := startConsuming(t, runner, binding, newTaskSupport(withAsyncAPIBinding("kafka", binding), withClock(clock)))
+
+		clock.advance(t, time.Minute)
+		r := <-result
+		assert.NoError(t, r.err)
+		assert.Equal(t, []interface{}{}, r.output)
+	})
+
+	t.Run("missing binding is a configuration error", func(t *testing.T) {
+		runner, err := NewCallAsyncAPITaskRunner("consume", newConsumeOrdersTask(t, &model.AsyncAPISubscription{
+			Consume: &model.AsyncAPIMessageConsumptionPolicy{Amount: 1},
+		}))
+		assert.NoError(t, err)
+		_, err = runner.Run(nil, newTaskSupport())
+		assert.True(t, model.IsErrConfiguration(err))
+	})
+}
+
+func TestMemoryAsyncAPIBinding_CloseWhilePublishing(t *testing.T) {
+	binding := NewMemoryAsyncAPIBinding()
+	subscription, err := binding.Subscribe(context.Background(), ordersEndpoint)
+	assert.NoError(t, err)
+	for i := 0; i < memoryAsyncAPIBuffer; i++ {
+		publishOrder(t, binding, "order", 1)
+	}
+
+	published := make(chan error, 1)
+	go func() {
+		published <- binding.Publish(context.Background(), ordersEndpoint, &AsyncAPIMessage{Payload: "blocked"})
+	}()
+	// let the publisher block on the full subscription, as when a subscribe operation reached its amount
+	time.Sleep(10 * time.Millisecond)
+
+	closed := make(chan error, 1)
+	go func() {
+		closed <- subscription.Close()
+	}()
+	for _, done := range []chan error{closed, published} {
+		select {
+		case err := <-done:
+			assert.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("closing a full subscription deadlocked with the publisher")
+		}
+	}
+	assert.Equal(t, 0, binding.Subscribers(ordersEndpoint))
+}
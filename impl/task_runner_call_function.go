@@ -0,0 +1,168 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/serverlessworkflow/sdk-go/v3/impl/expr"
+	"github.com/serverlessworkflow/sdk-go/v3/impl/utils"
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+)
+
+// maxFunctionCallDepth bounds the nesting of function calls: unlike the ones of `use.functions`, the functions of
+// catalogs are not checked for cycles before they run.
+const maxFunctionCallDepth = 64
+
+// functionCallDepthKey is the key of the depth of the function calls in the context of the tasks.
+type functionCallDepthKey struct{}
+
+func NewCallFunctionTaskRunner(taskName string, task *model.CallFunction, workflowDef *model.Workflow) (*CallFunctionTaskRunner, error) {
+	if task == nil || task.Call == "" {
+		return nil, model.NewErrValidation(fmt.Errorf("invalid Call task %s", taskName), taskName)
+	}
+	var use model.Use
+	if workflowDef != nil && workflowDef.Use != nil {
+		use = *workflowDef.Use
+	}
+	runner := &CallFunctionTaskRunner{
+		Task:      task,
+		TaskName:  taskName,
+		Reference: task.AsCatalogReference(),
+	}
+	if runner.Reference == nil {
+		if runner.Function = use.Functions[task.Call]; runner.Function == nil {
+			return nil, model.NewErrValidation(fmt.Errorf("task %s calls the undeclared function '%s'", taskName, task.Call), taskName)
+		}
+		return runner, nil
+	}
+	if runner.Catalog = use.Catalogs[runner.Reference.Catalog]; runner.Catalog == nil && runner.Reference.Catalog != model.DefaultCatalog {
+		return nil, model.NewErrValidation(fmt.Errorf("task %s calls a function of the undeclared catalog '%s'", taskName, runner.Reference.Catalog), taskName)
+	}
+	return runner, nil
+}
+
+type CallFunctionTaskRunner struct {
+	Task     *model.CallFunction
+	TaskName string
+	// Function is the function declared in `use.functions`, nil for the functions of catalogs
+	Function  model.Task
+	Reference *model.CatalogFunctionReference
+	// Catalog is the catalog declared in `use.catalogs`, nil for the default catalog
+	Catalog *model.Catalog
+}
+
+// Run runs the function, declared in `use.functions` or loaded from its catalog, with the task input. The `with`
+// arguments, evaluated against the input, are available to the function as expression variables, e.g. `$petId`
+// for the `petId` argument.
+func (c *CallFunctionTaskRunner) Run(input interface{}, taskSupport TaskSupport) (interface{}, error) {
+	depth, _ := taskSupport.GetContext().Value(functionCallDepthKey{}).(int)
+	if depth >= maxFunctionCallDepth {
+		return nil, model.NewErrConfiguration(fmt.Errorf("function calls nest deeper than %d, %s may call itself endlessly", maxFunctionCallDepth, c.Task.Call), c.TaskName)
+	}
+	taskSupport = taskSupport.WithContext(context.WithValue(taskSupport.GetContext(), functionCallDepthKey{}, depth+1))
+
+	function, err := c.resolveFunction(input, taskSupport)
+	if err != nil {
+		return nil, err
+	}
+	// created on demand, like the tasks of a list, as functions may call functions
+	runner, err := NewTaskRunner(c.Task.Call, function, taskSupport.GetWorkflowDef())
+	if err != nil {
+		return nil, err
+	}
+
+	args := map[string]interface{}{}
+	if c.Task.With != nil {
+		evaluated, err := expr.TraverseAndEvaluate(utils.DeepClone(c.Task.With), input, taskSupport.GetContext())
+		if err != nil {
+			return nil, model.NewErrExpression(err, c.TaskName)
+		}
+		args = evaluated.(map[string]interface{})
+	}
+
+	vars := make(map[string]interface{}, len(args))
+	keys := make([]string, 0, len(args))
+	for name, value := range args {
+		vars["$"+name] = value
+		keys = append(keys, "$"+name)
+	}
+	taskSupport.AddLocalExprVars(vars)
+	defer taskSupport.RemoveLocalExprVars(keys...)
+
+	// the function is a task of its own, with its input, output and export processing, and timeout
+	return (&DoTaskRunner{}).runTask(input, taskSupport, runner, function.GetBase())
+}
+
+// resolveFunction loads the function of a catalog through the runner's CatalogResolver.
+func (c *CallFunctionTaskRunner) resolveFunction(input interface{}, taskSupport TaskSupport) (model.Task, error) {
+	if c.Reference == nil {
+		return c.Function, nil
+	}
+	resolver := configOf(taskSupport).GetCatalogResolver()
+	if resolver == nil {
+		return nil, model.NewErrConfiguration(fmt.Errorf("no catalog resolver configured to call %s", c.Task.Call), c.TaskName)
+	}
+
+	var endpoint string
+	if c.Catalog != nil {
+		if config := c.Catalog.Endpoint.EndpointConfig; config != nil && config.Authentication != nil {
+			return nil, model.NewErrConfiguration(fmt.Errorf("catalog %s: authenticated endpoints are not supported, configure the client of the catalog resolver instead", c.Reference.Catalog), c.TaskName)
+		}
+		var err error
+		if endpoint, err = evaluateEndpoint(c.Catalog.Endpoint, input, c.TaskName, taskSupport); err != nil {
+			return nil, err
+		}
+	}
+
+	function, err := resolver.Resolve(taskSupport.GetContext(), endpoint, c.Reference.Name, c.Reference.Version)
+	switch {
+	case err == nil:
+		return function, c.checkSecrets(function, taskSupport.GetWorkflowDef())
+	case taskSupport.GetContext().Err() != nil:
+		return nil, newContextErr(taskSupport.GetContext().Err(), c.TaskName)
+	case errors.Is(err, ErrCatalogUnavailable):
+		return nil, model.NewErrCommunication(fmt.Errorf("failed to resolve %s: %w", c.Task.Call, err), c.TaskName)
+	default:
+		return nil, model.NewErrConfiguration(fmt.Errorf("failed to resolve %s: %w", c.Task.Call, err), c.TaskName)
+	}
+}
+
+// checkSecrets rejects the functions of catalogs accessing secrets that the workflow does not declare in
+// `use.secrets`, which validating the workflow could not catch.
+func (c *CallFunctionTaskRunner) checkSecrets(function model.Task, workflow *model.Workflow) error {
+	names, err := model.SecretReferences(function)
+	if err != nil {
+		return model.NewErrConfiguration(fmt.Errorf("invalid definition of %s: %w", c.Task.Call, err), c.TaskName)
+	}
+	declared := map[string]bool{}
+	if workflow != nil && workflow.Use != nil {
+		for _, name := range workflow.Use.Secrets {
+			declared[name] = true
+		}
+	}
+	for _, name := range names {
+		if !declared[name] {
+			return model.NewErrConfiguration(fmt.Errorf("function %s uses secret %s: %w", c.Task.Call, name, ErrSecretNotDeclared), c.TaskName)
+		}
+	}
+	return nil
+}
+
+func (c *CallFunctionTaskRunner) GetTaskName() string {
+	return c.TaskName
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/serverlessworkflow/sdk-go/v3/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCallFunctionTaskRunner_Run(t *testing.T) {
+	t.Run("functions run with their arguments as variables", func(t *testing.T) {
+		server := newPetServer(t)
+		workflow, err := parser.FromFile("./testdata/call_function.yaml")
+		assert.NoError(t, err)
+
+		runner, err := NewDefaultRunner(workflow, WithHTTPClient(server.Client()))
+		assert.NoError(t, err)
+		output, err := runner.Run(map[string]interface{}{"baseUrl": server.URL, "petId": "7"})
+		assert.NoError(t, err)
+		assert.Equal(t, "pet 7", output)
+	})
+
+	t.Run("arguments do not outlive the call", func(t *testing.T) {
+		workflow := &model.Workflow{
+			Use: &model.Use{Functions: model.NamedTaskMap{
+				"greet": &model.SetTask{Set: map[string]interface{}{"greeting": "${ \"Hello \" + $name }"}},
+			}},
+			Do: &model.TaskList{{Key: "greetOwner", Task: &model.CallFunction{Call: "greet", With: map[string]interface{}{"name": "${ .owner }"}}}},
+		}
+		runner, err := NewDefaultRunner(workflow)
+		assert.NoError(t, err)
+
+		output, err := runner.Run(map[string]interface{}{"owner": "Alice"})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"greeting": "Hello Alice"}, output)
+		assert.NotContains(t, runner.GetWorkflowCtx().GetVars(), "$name")
+	})
+
+	t.Run("undeclared function fails validation", func(t *testing.T) {
+		_, err := parser.FromYAMLSource([]byte(`
+document:
+  dsl: '1.0.0'
+  namespace: test
+  name: call-function
+  version: '1.0.0'
+do:
+  - findPet:
+      call: getPet
+`))
+		assert.ErrorContains(t, err, "function_reference")
+
+		_, err = NewCallFunctionTaskRunner("findPet", &model.CallFunction{Call: "getPet"}, &model.Workflow{})
+		assert.True(t, model.IsErrValidation(err))
+	})
+}
@@ -0,0 +1,238 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/bufbuild/protocompile"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/serverlessworkflow/sdk-go/v3/impl/auth"
+	"github.com/serverlessworkflow/sdk-go/v3/impl/expr"
+	"github.com/serverlessworkflow/sdk-go/v3/impl/utils"
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+)
+
+const grpcTLSPort = 443
+
+type CallGRPCTaskRunner struct {
+	Task     *model.CallGRPC
+	TaskName string
+}
+
+func NewCallGRPCTaskRunner(taskName string, task *model.CallGRPC) (*CallGRPCTaskRunner, error) {
+	if task == nil || task.With.Proto == nil || task.With.Method == "" || task.With.Service.Name == "" {
+		return nil, model.NewErrValidation(fmt.Errorf("invalid gRPC call task %s", taskName), taskName)
+	}
+	return &CallGRPCTaskRunner{Task: task, TaskName: taskName}, nil
+}
+
+// Run compiles the proto file at runtime, builds the request message from the arguments and invokes the unary
+// method. The response message is returned in its JSON form.
+func (g *CallGRPCTaskRunner) Run(input interface{}, taskSupport TaskSupport) (interface{}, error) {
+	args := g.Task.With
+	source, location, err := loadExternalResource(args.Proto, input, g.TaskName, taskSupport)
+	if err != nil {
+		return nil, err
+	}
+	method, err := findGRPCMethod(taskSupport.GetContext(), location, source, args.Service.Name, args.Method)
+	if err != nil {
+		return nil, model.NewErrConfiguration(err, g.TaskName)
+	}
+	request, err := g.newRequest(method, input, taskSupport)
+	if err != nil {
+		return nil, err
+	}
+	transport, secure := grpcTransportCredentials(args.Service.Port, configOf(taskSupport).GetGRPCTLSConfig())
+	rpcCtx, err := g.authenticate(input, secure, taskSupport)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := grpc.NewClient(net.JoinHostPort(args.Service.Host, strconv.Itoa(args.Service.Port)),
+		grpc.WithTransportCredentials(transport))
+	if err != nil {
+		return nil, model.NewErrConfiguration(fmt.Errorf("invalid gRPC target: %w", err), g.TaskName)
+	}
+	defer conn.Close()
+
+	response := dynamicpb.NewMessage(method.Output())
+	fullMethod := "/" + string(method.Parent().FullName()) + "/" + string(method.Name())
+	if err := conn.Invoke(rpcCtx, fullMethod, request, response); err != nil {
+		if ctxErr := taskSupport.GetContext().Err(); ctxErr != nil {
+			return nil, newContextErr(ctxErr, g.TaskName)
+		}
+		return nil, newGRPCStatusError(fullMethod, err, g.TaskName)
+	}
+	return grpcMessageToJSON(response, g.TaskName)
+}
+
+func (g *CallGRPCTaskRunner) GetTaskName() string {
+	return g.TaskName
+}
+
+// newRequest evaluates the arguments and sets them on a request message, using the protobuf JSON mapping.
+func (g *CallGRPCTaskRunner) newRequest(method protoreflect.MethodDescriptor, input interface{}, taskSupport TaskSupport) (*dynamicpb.Message, error) {
+	request := dynamicpb.NewMessage(method.Input())
+	if len(g.Task.With.Arguments) == 0 {
+		return request, nil
+	}
+	arguments, err := expr.TraverseAndEvaluate(utils.DeepClone(g.Task.With.Arguments), input, taskSupport.GetContext())
+	if err != nil {
+		return nil, model.NewErrExpression(err, g.TaskName)
+	}
+	payload, err := json.Marshal(arguments)
+	if err != nil {
+		return nil, model.NewErrValidation(fmt.Errorf("invalid arguments for gRPC method %s: %w", method.FullName(), err), g.TaskName)
+	}
+	if err := protojson.Unmarshal(payload, request); err != nil {
+		return nil, model.NewErrValidation(fmt.Errorf("invalid arguments for gRPC method %s: %w", method.FullName(), err), g.TaskName)
+	}
+	return request, nil
+}
+
+// authenticate returns the task context carrying the credentials of the call policy, or of the service one, as
+// `authorization` metadata. Like grpc-go's per-RPC credentials, they are never sent over an insecure connection.
+func (g *CallGRPCTaskRunner) authenticate(input interface{}, secure bool, taskSupport TaskSupport) (context.Context, error) {
+	ref := g.Task.With.Authentication
+	if ref == nil {
+		ref = g.Task.With.Service.Authentication
+	}
+	policy, err := auth.ResolvePolicy(ref, taskSupport.GetWorkflowDef())
+	if err != nil {
+		return nil, model.NewErrConfiguration(err, g.TaskName)
+	}
+	if policy == nil {
+		return taskSupport.GetContext(), nil
+	}
+	if !secure {
+		return nil, model.NewErrConfiguration(fmt.Errorf("refusing to send credentials over an insecure connection to port %d, configure TLS with WithGRPCTLSConfig", g.Task.With.Service.Port), g.TaskName)
+	}
+	if policy, err = evaluateAuthenticationPolicy(policy, input, g.TaskName, taskSupport); err != nil {
+		return nil, err
+	}
+	authorization, err := auth.Authorization(taskSupport.GetContext(), policy, configOf(taskSupport).GetTokenProvider())
+	if errors.Is(err, auth.ErrAuthenticationFailed) {
+		return nil, model.NewErrAuthentication(err, g.TaskName)
+	}
+	if err != nil {
+		return nil, model.NewErrConfiguration(err, g.TaskName)
+	}
+	return metadata.AppendToOutgoingContext(taskSupport.GetContext(), "authorization", authorization), nil
+}
+
+// grpcTransportCredentials returns the credentials of the connection, and whether it is secured with TLS.
+func grpcTransportCredentials(port int, config *tls.Config) (credentials.TransportCredentials, bool) {
+	if config != nil {
+		return credentials.NewTLS(config), true
+	}
+	if port == grpcTLSPort {
+		return credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12}), true
+	}
+	return insecure.NewCredentials(), false
+}
+
+// findGRPCMethod compiles the proto file and looks up the method of the service, named either by its simple or fully
+// qualified name. Imports are resolved against the well-known types and, for local files, the file's directory.
+func findGRPCMethod(ctx context.Context, location *url.URL, source []byte, serviceName, methodName string) (protoreflect.MethodDescriptor, error) {
+	fileName := path.Base(location.Path)
+	var importDir string
+	if location.Scheme == "file" {
+		importDir = filepath.Dir(filepath.FromSlash(location.Path))
+	}
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{
+			Accessor: func(name string) (io.ReadCloser, error) {
+				if name == fileName {
+					return io.NopCloser(strings.NewReader(string(source))), nil
+				}
+				if importDir == "" {
+					return nil, fmt.Errorf("cannot import %s from remote proto file %s", name, location)
+				}
+				return os.Open(filepath.Join(importDir, filepath.FromSlash(name)))
+			},
+		}),
+	}
+	files, err := compiler.Compile(ctx, fileName)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proto file %s: %w", location, err)
+	}
+
+	services := files[0].Services()
+	for i := 0; i < services.Len(); i++ {
+		service := services.Get(i)
+		if string(service.Name()) != serviceName && string(service.FullName()) != serviceName {
+			continue
+		}
+		method := service.Methods().ByName(protoreflect.Name(methodName))
+		if method == nil {
+			return nil, fmt.Errorf("method %s not found in service %s", methodName, service.FullName())
+		}
+		if method.IsStreamingClient() || method.IsStreamingServer() {
+			return nil, fmt.Errorf("streaming method %s of service %s is not supported", methodName, service.FullName())
+		}
+		return method, nil
+	}
+	return nil, fmt.Errorf("service %s not found in proto file %s", serviceName, location)
+}
+
+func grpcMessageToJSON(message *dynamicpb.Message, instance string) (interface{}, error) {
+	payload, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(message)
+	if err != nil {
+		return nil, model.NewErrRuntime(fmt.Errorf("failed to convert gRPC response: %w", err), instance)
+	}
+	var output interface{}
+	if err := json.Unmarshal(payload, &output); err != nil {
+		return nil, model.NewErrRuntime(fmt.Errorf("failed to convert gRPC response: %w", err), instance)
+	}
+	return output, nil
+}
+
+// newGRPCStatusError maps the status of a failed call to a workflow error: unauthenticated and permission denied
+// calls to authentication and authorization errors, deadlines to timeouts and anything else to a communication error.
+func newGRPCStatusError(fullMethod string, err error, instance string) *model.Error {
+	st := status.Convert(err)
+	detail := fmt.Errorf("gRPC call %s failed with status %s: %s", fullMethod, st.Code(), st.Message())
+	switch st.Code() {
+	case codes.Unauthenticated:
+		return model.NewErrAuthentication(detail, instance)
+	case codes.PermissionDenied:
+		return model.NewErrAuthorization(detail, instance)
+	case codes.DeadlineExceeded:
+		return model.NewErrTimeout(detail, instance)
+	}
+	return model.NewErrCommunication(detail, instance)
+}
@@ -0,0 +1,183 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/serverlessworkflow/sdk-go/v3/parser"
+)
+
+func greeterProtoURI(t *testing.T) string {
+	path, err := filepath.Abs("./testdata/grpc/greeter.proto")
+	assert.NoError(t, err)
+	return fileURI(path)
+}
+
+// newGreeterServer starts an in-process Greeter service, without generated code, and returns its port.
+// Calls carrying an authorization other than `Bearer abc` are rejected.
+func newGreeterServer(t *testing.T, opts ...grpc.ServerOption) int {
+	location, err := url.Parse(greeterProtoURI(t))
+	assert.NoError(t, err)
+	source, err := os.ReadFile("./testdata/grpc/greeter.proto")
+	assert.NoError(t, err)
+	method, err := findGRPCMethod(context.Background(), location, source, "Greeter", "SayHello")
+	assert.NoError(t, err)
+
+	handler := func(_ interface{}, stream grpc.ServerStream) error {
+		if md, _ := metadata.FromIncomingContext(stream.Context()); len(md.Get("authorization")) > 0 && md.Get("authorization")[0] != "Bearer abc" {
+			return status.Error(codes.Unauthenticated, "invalid token")
+		}
+		request := dynamicpb.NewMessage(method.Input())
+		if err := stream.RecvMsg(request); err != nil {
+			return err
+		}
+		name := request.Get(method.Input().Fields().ByName("name")).String()
+		if name == "" {
+			return status.Error(codes.InvalidArgument, "name is required")
+		}
+		reply := dynamicpb.NewMessage(method.Output())
+		reply.Set(method.Output().Fields().ByName("message"), protoreflect.ValueOfString("Hello "+name))
+		tags := request.Get(method.Input().Fields().ByName("tags")).List().Len()
+		reply.Set(method.Output().Fields().ByName("tag_count"), protoreflect.ValueOfInt32(int32(tags)))
+		return stream.SendMsg(reply)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := grpc.NewServer(append(opts, grpc.UnknownServiceHandler(handler))...)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+func newCallGRPCTask(t *testing.T, port int, method string, arguments map[string]interface{}) *model.CallGRPC {
+	return &model.CallGRPC{
+		Call: "grpc",
+		With: model.GRPCArguments{
+			Proto:     &model.ExternalResource{Endpoint: model.NewEndpoint(greeterProtoURI(t))},
+			Service:   model.GRPCService{Name: "Greeter", Host: "127.0.0.1", Port: port},
+			Method:    method,
+			Arguments: arguments,
+		},
+	}
+}
+
+func newBearerCallGRPCTask(t *testing.T, port int) *model.CallGRPC {
+	task := newCallGRPCTask(t, port, "SayHello", map[string]interface{}{"name": "Bob"})
+	task.With.Authentication = &model.ReferenceableAuthenticationPolicy{
+		AuthenticationPolicy: &model.AuthenticationPolicy{Bearer: &model.BearerAuthenticationPolicy{Token: "${ .token }"}},
+	}
+	return task
+}
+
+func TestCallGRPCTaskRunner_Workflow(t *testing.T) {
+	port := newGreeterServer(t)
+	workflow, err := parser.FromFile("./testdata/call_grpc.yaml")
+	assert.NoError(t, err)
+	(*workflow.Do)[0].AsCallGRPCTask().With.Service.Port = port
+
+	runner, err := NewDefaultRunner(workflow)
+	assert.NoError(t, err)
+	output, err := runner.Run(map[string]interface{}{"protoUri": greeterProtoURI(t), "name": "Alice"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"message": "Hello Alice", "tag_count": float64(1)}, output)
+}
+
+func TestCallGRPCTaskRunner_Run(t *testing.T) {
+	port := newGreeterServer(t)
+
+	t.Run("credentials are never sent over an insecure connection", func(t *testing.T) {
+		runner, err := NewCallGRPCTaskRunner("greet", newBearerCallGRPCTask(t, port))
+		assert.NoError(t, err)
+		_, err = runner.Run(map[string]interface{}{"token": "abc"}, newTaskSupport())
+		assert.True(t, model.IsErrConfiguration(err))
+		assert.ErrorContains(t, err, "insecure connection")
+	})
+
+	t.Run("error status is a communication error", func(t *testing.T) {
+		runner, err := NewCallGRPCTaskRunner("greet", newCallGRPCTask(t, port, "SayHello", nil))
+		assert.NoError(t, err)
+		_, err = runner.Run(nil, newTaskSupport())
+		assert.True(t, model.IsErrCommunication(err))
+		assert.ErrorContains(t, err, "InvalidArgument")
+	})
+
+	t.Run("arguments not matching the request message are a validation error", func(t *testing.T) {
+		runner, err := NewCallGRPCTaskRunner("greet", newCallGRPCTask(t, port, "SayHello", map[string]interface{}{"age": 3}))
+		assert.NoError(t, err)
+		_, err = runner.Run(nil, newTaskSupport())
+		assert.True(t, model.IsErrValidation(err))
+	})
+
+	t.Run("unknown and streaming methods are configuration errors", func(t *testing.T) {
+		for _, method := range []string{"SayGoodbye", "StreamHellos"} {
+			runner, err := NewCallGRPCTaskRunner("greet", newCallGRPCTask(t, port, method, nil))
+			assert.NoError(t, err)
+			_, err = runner.Run(nil, newTaskSupport())
+			assert.True(t, model.IsErrConfiguration(err), method)
+		}
+	})
+}
+
+func TestCallGRPCTaskRunner_TLS(t *testing.T) {
+	// Borrow the certificate of a TLS test server, valid for 127.0.0.1.
+	certSource := httptest.NewTLSServer(nil)
+	certSource.Close()
+	roots := x509.NewCertPool()
+	roots.AddCert(certSource.Certificate())
+
+	port := newGreeterServer(t, grpc.Creds(credentials.NewTLS(&tls.Config{Certificates: certSource.TLS.Certificates})))
+	runner, err := NewCallGRPCTaskRunner("greet", newCallGRPCTask(t, port, "SayHello", map[string]interface{}{"name": "Carol"}))
+	assert.NoError(t, err)
+
+	output, err := runner.Run(nil, newTaskSupport(withGRPCTLSConfig(&tls.Config{RootCAs: roots})))
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello Carol", output.(map[string]interface{})["message"])
+
+	_, err = runner.Run(nil, newTaskSupport())
+	assert.True(t, model.IsErrCommunication(err))
+
+	t.Run("bearer token is sent as metadata", func(t *testing.T) {
+		runner, err := NewCallGRPCTaskRunner("greet", newBearerCallGRPCTask(t, port))
+		assert.NoError(t, err)
+		support := newTaskSupport(withGRPCTLSConfig(&tls.Config{RootCAs: roots}))
+
+		output, err := runner.Run(map[string]interface{}{"token": "abc"}, support)
+		assert.NoError(t, err)
+		assert.Equal(t, "Hello Bob", output.(map[string]interface{})["message"])
+
+		_, err = runner.Run(map[string]interface{}{"token": "wrong"}, support)
+		assert.True(t, model.IsErrAuthentication(err))
+	})
+}
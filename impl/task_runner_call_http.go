@@ -15,30 +15,438 @@
 package impl
 
 import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
 
+	"github.com/serverlessworkflow/sdk-go/v3/impl/auth"
+	"github.com/serverlessworkflow/sdk-go/v3/impl/expr"
+	"github.com/serverlessworkflow/sdk-go/v3/impl/utils"
 	"github.com/serverlessworkflow/sdk-go/v3/model"
 )
 
+const (
+	httpOutputContent  = "content"
+	httpOutputResponse = "response"
+	httpOutputRaw      = "raw"
+)
+
+var uriTemplateVarPattern = regexp.MustCompile(`\{([^{}]+)}`)
+
 type CallHTTPTaskRunner struct {
+	Task     *model.CallHTTP
 	TaskName string
 }
 
 func NewCallHttpRunner(taskName string, task *model.CallHTTP) (taskRunner *CallHTTPTaskRunner, err error) {
-	if task == nil {
-		err = model.NewErrValidation(fmt.Errorf("invalid For task %s", taskName), taskName)
+	if task == nil || task.With.Endpoint == nil {
+		err = model.NewErrValidation(fmt.Errorf("invalid HTTP call task %s", taskName), taskName)
 	} else {
 		taskRunner = new(CallHTTPTaskRunner)
+		taskRunner.Task = task
 		taskRunner.TaskName = taskName
 	}
 	return
 }
 
 func (f *CallHTTPTaskRunner) Run(input interface{}, taskSupport TaskSupport) (interface{}, error) {
-	return input, nil
-
+	request, err := f.evaluateRequest(input, taskSupport)
+	if err != nil {
+		return nil, err
+	}
+	var authentication *model.ReferenceableAuthenticationPolicy
+	if f.Task.With.Endpoint.EndpointConfig != nil {
+		authentication = f.Task.With.Endpoint.EndpointConfig.Authentication
+	}
+	client, err := newAuthenticatedHTTPClient(authentication, input, f.TaskName, taskSupport)
+	if err != nil {
+		return nil, err
+	}
+	request.Authenticated = authentication != nil
+	return doHTTPCall(taskSupport.GetContext(), client, request, taskSupport.GetTaskReference())
 }
 
 func (f *CallHTTPTaskRunner) GetTaskName() string {
 	return f.TaskName
 }
+
+// evaluateRequest resolves every runtime expression in the task arguments against the task input.
+func (f *CallHTTPTaskRunner) evaluateRequest(input interface{}, taskSupport TaskSupport) (*httpCallRequest, error) {
+	args := f.Task.With
+	request := &httpCallRequest{
+		Method:   strings.ToUpper(args.Method),
+		Output:   args.Output,
+		Redirect: args.Redirect,
+	}
+
+	var err error
+	if request.URI, err = evaluateEndpoint(args.Endpoint, input, f.TaskName, taskSupport); err != nil {
+		return nil, err
+	}
+
+	if len(args.Headers) > 0 {
+		headers := make(map[string]interface{}, len(args.Headers))
+		for k, v := range args.Headers {
+			headers[k] = v
+		}
+		evaluated, err := expr.TraverseAndEvaluate(headers, input, taskSupport.GetContext())
+		if err != nil {
+			return nil, model.NewErrExpression(err, f.TaskName)
+		}
+		request.Headers = make(map[string]string, len(headers))
+		for k, v := range evaluated.(map[string]interface{}) {
+			request.Headers[k] = fmt.Sprintf("%v", v)
+		}
+	}
+
+	if len(args.Query) > 0 {
+		evaluated, err := expr.TraverseAndEvaluate(utils.DeepClone(args.Query), input, taskSupport.GetContext())
+		if err != nil {
+			return nil, model.NewErrExpression(err, f.TaskName)
+		}
+		request.Query = evaluated.(map[string]interface{})
+	}
+
+	if len(args.Body) > 0 {
+		var body interface{}
+		if err := json.Unmarshal(args.Body, &body); err != nil {
+			return nil, model.NewErrValidation(fmt.Errorf("invalid body for HTTP call task %s: %w", f.TaskName, err), f.TaskName)
+		}
+		if request.Body, err = expr.TraverseAndEvaluate(body, input, taskSupport.GetContext()); err != nil {
+			return nil, model.NewErrExpression(err, f.TaskName)
+		}
+	}
+
+	return request, nil
+}
+
+// evaluateEndpoint resolves a model.Endpoint to a concrete URI, evaluating runtime expressions and
+// expanding URI template variables from the given input.
+func evaluateEndpoint(endpoint *model.Endpoint, input interface{}, taskName string, taskSupport TaskSupport) (string, error) {
+	if endpoint == nil {
+		return "", model.NewErrValidation(fmt.Errorf("missing endpoint for task %s", taskName), taskName)
+	}
+
+	runtimeExpr := endpoint.RuntimeExpression
+	uriTemplate := endpoint.URITemplate
+	if endpoint.EndpointConfig != nil {
+		runtimeExpr = endpoint.EndpointConfig.RuntimeExpression
+		uriTemplate = endpoint.EndpointConfig.URI
+	}
+
+	switch {
+	case runtimeExpr != nil:
+		result, err := expr.TraverseAndEvaluate(model.NormalizeExpr(runtimeExpr.String()), input, taskSupport.GetContext())
+		if err != nil {
+			return "", model.NewErrExpression(err, taskName)
+		}
+		uri, ok := result.(string)
+		if !ok {
+			return "", model.NewErrExpression(fmt.Errorf("endpoint expression '%s' must evaluate to a string, got %T", runtimeExpr.String(), result), taskName)
+		}
+		return uri, nil
+	case uriTemplate != nil:
+		return expandURITemplate(uriTemplate.String(), input, taskName)
+	}
+
+	return "", model.NewErrValidation(fmt.Errorf("endpoint for task %s has no URI", taskName), taskName)
+}
+
+// expandURITemplate replaces simple `{var}` template variables with the matching top-level input values.
+func expandURITemplate(uriTemplate string, input interface{}, taskName string) (string, error) {
+	if !strings.Contains(uriTemplate, "{") {
+		return uriTemplate, nil
+	}
+	vars, _ := input.(map[string]interface{})
+	var missing []string
+	expanded := uriTemplateVarPattern.ReplaceAllStringFunc(uriTemplate, func(match string) string {
+		name := match[1 : len(match)-1]
+		value, ok := vars[name]
+		if !ok || value == nil {
+			missing = append(missing, name)
+			return match
+		}
+		return url.PathEscape(fmt.Sprintf("%v", value))
+	})
+	if len(missing) > 0 {
+		return "", model.NewErrValidation(fmt.Errorf("missing values for URI template variables %v in '%s'", missing, uriTemplate), taskName)
+	}
+	return expanded, nil
+}
+
+// newAuthenticatedHTTPClient resolves the authentication policy, either inline or from `use.authentications`, and returns
+// a client that attaches its credentials to every request. Without a policy, the shared client is returned.
+func newAuthenticatedHTTPClient(ref *model.ReferenceableAuthenticationPolicy, input interface{}, taskName string, taskSupport TaskSupport) (*http.Client, error) {
+	policy, err := auth.ResolvePolicy(ref, taskSupport.GetWorkflowDef())
+	if err != nil {
+		return nil, model.NewErrConfiguration(err, taskName)
+	}
+	if policy == nil {
+		return configOf(taskSupport).GetHTTPClient(), nil
+	}
+	if policy, err = evaluateAuthenticationPolicy(policy, input, taskName, taskSupport); err != nil {
+		return nil, err
+	}
+	client, err := auth.NewClient(configOf(taskSupport).GetHTTPClient(), policy, configOf(taskSupport).GetTokenProvider())
+	if err != nil {
+		return nil, model.NewErrConfiguration(err, taskName)
+	}
+	return client, nil
+}
+
+// evaluateAuthenticationPolicy returns a copy of the policy with the runtime expressions in its credentials evaluated
+// and the credentials it takes from a secret resolved.
+func evaluateAuthenticationPolicy(policy *model.AuthenticationPolicy, input interface{}, taskName string, taskSupport TaskSupport) (*model.AuthenticationPolicy, error) {
+	evaluated := *policy
+	var err error
+	eval := func(values ...*string) {
+		for _, value := range values {
+			if err != nil || !model.IsStrictExpr(*value) {
+				continue
+			}
+			var result interface{}
+			if result, err = expr.TraverseAndEvaluate(*value, input, taskSupport.GetContext()); err == nil {
+				*value = fmt.Sprintf("%v", result)
+			}
+		}
+	}
+	switch {
+	case policy.Basic != nil:
+		basic := *policy.Basic
+		eval(&basic.Username, &basic.Password)
+		evaluated.Basic = &basic
+	case policy.Bearer != nil:
+		bearer := *policy.Bearer
+		eval(&bearer.Token)
+		evaluated.Bearer = &bearer
+	case policy.Digest != nil:
+		digest := *policy.Digest
+		eval(&digest.Username, &digest.Password)
+		evaluated.Digest = &digest
+	case policy.OAuth2 != nil && policy.OAuth2.Properties != nil:
+		oauth2 := *policy.OAuth2
+		oauth2.Properties = evaluateOAuth2Properties(policy.OAuth2.Properties, eval)
+		evaluated.OAuth2 = &oauth2
+	case policy.OIDC != nil && policy.OIDC.Properties != nil:
+		oidc := *policy.OIDC
+		oidc.Properties = evaluateOAuth2Properties(policy.OIDC.Properties, eval)
+		evaluated.OIDC = &oidc
+	}
+	if err != nil {
+		return nil, model.NewErrExpression(err, taskName)
+	}
+	return resolvePolicySecret(&evaluated, taskName, taskSupport)
+}
+
+// evaluateOAuth2Properties copies the OAuth2 properties and evaluates the credentials they carry.
+func evaluateOAuth2Properties(props *model.OAuth2AuthenticationProperties, eval func(values ...*string)) *model.OAuth2AuthenticationProperties {
+	evaluated := *props
+	eval(&evaluated.Username, &evaluated.Password)
+	if props.Client != nil {
+		client := *props.Client
+		eval(&client.ID, &client.Secret, &client.Assertion)
+		evaluated.Client = &client
+	}
+	if props.Subject != nil {
+		subject := *props.Subject
+		eval(&subject.Token)
+		evaluated.Subject = &subject
+	}
+	if props.Actor != nil {
+		actor := *props.Actor
+		eval(&actor.Token)
+		evaluated.Actor = &actor
+	}
+	return &evaluated
+}
+
+// httpCallRequest is the evaluated form of an HTTP call, shared by the call runners that speak HTTP.
+type httpCallRequest struct {
+	Method   string
+	URI      string
+	Headers  map[string]string
+	Query    map[string]interface{}
+	Body     interface{}
+	Output   string
+	Redirect bool
+	// Authenticated marks requests sent with an authentication policy, whose 401 and 403 responses are
+	// reported as authentication and authorization errors.
+	Authenticated bool
+}
+
+func (r *httpCallRequest) toHTTPRequest(ctx context.Context) (*http.Request, error) {
+	target, err := url.Parse(r.URI)
+	if err != nil {
+		return nil, err
+	}
+	if len(r.Query) > 0 {
+		query := target.Query()
+		for k, v := range r.Query {
+			if values, ok := v.([]interface{}); ok {
+				for _, item := range values {
+					query.Add(k, fmt.Sprintf("%v", item))
+				}
+				continue
+			}
+			query.Set(k, fmt.Sprintf("%v", v))
+		}
+		target.RawQuery = query.Encode()
+	}
+
+	var body io.Reader
+	contentType := ""
+	switch b := r.Body.(type) {
+	case nil:
+	case string:
+		body = strings.NewReader(b)
+		contentType = "text/plain"
+	default:
+		payload, err := json.Marshal(b)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(payload)
+		contentType = "application/json"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, r.Method, target.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range r.Headers {
+		req.Header.Set(k, v)
+	}
+	if contentType != "" && req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return req, nil
+}
+
+// doHTTPCall sends the request and shapes the result according to the requested output format.
+// Responses outside the 2xx range (3xx included when redirects are allowed) are returned as communication errors.
+func doHTTPCall(ctx context.Context, client *http.Client, request *httpCallRequest, instance string) (interface{}, error) {
+	req, err := request.toHTTPRequest(ctx)
+	if err != nil {
+		return nil, model.NewErrRuntime(fmt.Errorf("failed to build HTTP request: %w", err), instance)
+	}
+	return sendHTTPRequest(client, req, request, instance)
+}
+
+func sendHTTPRequest(client *http.Client, req *http.Request, request *httpCallRequest, instance string) (interface{}, error) {
+	resp, err := httpClientFor(client, request.Redirect).Do(req)
+	if errors.Is(err, auth.ErrAuthenticationFailed) {
+		return nil, model.NewErrAuthentication(err, instance)
+	}
+	if err != nil {
+		return nil, model.NewErrCommunication(fmt.Errorf("%s %s failed: %w", req.Method, req.URL, err), instance)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, model.NewErrCommunication(fmt.Errorf("failed to read response from %s %s: %w", req.Method, req.URL, err), instance)
+	}
+
+	if !isAcceptedStatus(resp.StatusCode, request.Redirect) {
+		return nil, newHTTPStatusError(req, resp, request.Authenticated, instance)
+	}
+
+	return buildHTTPOutput(req, resp, body, request.Output, instance)
+}
+
+// newHTTPStatusError maps an unexpected response to an error carrying the HTTP status. Authenticated calls rejected
+// with 401 or 403 are reported as authentication or authorization errors, anything else as a communication error.
+func newHTTPStatusError(req *http.Request, resp *http.Response, authenticated bool, instance string) *model.Error {
+	detail := fmt.Errorf("%s %s responded with status %s", req.Method, req.URL, resp.Status)
+	var err *model.Error
+	switch {
+	case authenticated && resp.StatusCode == http.StatusUnauthorized:
+		err = model.NewErrAuthentication(detail, instance)
+	case authenticated && resp.StatusCode == http.StatusForbidden:
+		err = model.NewErrAuthorization(detail, instance)
+	default:
+		err = model.NewErrCommunication(detail, instance)
+	}
+	err.Status = resp.StatusCode
+	return err
+}
+
+func isAcceptedStatus(status int, redirect bool) bool {
+	if redirect {
+		return status >= 200 && status < 400
+	}
+	return status >= 200 && status < 300
+}
+
+// httpClientFor returns a client that only follows redirects when the call allows them.
+func httpClientFor(client *http.Client, redirect bool) *http.Client {
+	if redirect {
+		return client
+	}
+	noRedirect := *client
+	noRedirect.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return &noRedirect
+}
+
+func buildHTTPOutput(req *http.Request, resp *http.Response, body []byte, output string, instance string) (interface{}, error) {
+	switch output {
+	case httpOutputContent, "":
+		return decodeHTTPContent(resp, body, instance)
+	case httpOutputRaw:
+		return base64.StdEncoding.EncodeToString(body), nil
+	case httpOutputResponse:
+		content, err := decodeHTTPContent(resp, body, instance)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{
+			"request": map[string]interface{}{
+				"method":  req.Method,
+				"uri":     req.URL.String(),
+				"headers": flattenHeaders(req.Header),
+			},
+			"statusCode": resp.StatusCode,
+			"headers":    flattenHeaders(resp.Header),
+			"content":    content,
+		}, nil
+	default:
+		return nil, model.NewErrValidation(fmt.Errorf("unsupported HTTP call output format '%s'", output), instance)
+	}
+}
+
+// decodeHTTPContent deserializes JSON payloads and returns any other content as a string.
+func decodeHTTPContent(resp *http.Response, body []byte, instance string) (interface{}, error) {
+	if len(body) == 0 {
+		return nil, nil
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") {
+		var content interface{}
+		if err := json.Unmarshal(body, &content); err != nil {
+			return nil, model.NewErrCommunication(fmt.Errorf("failed to decode JSON response: %w", err), instance)
+		}
+		return content, nil
+	}
+	return string(body), nil
+}
+
+// flattenHeaders maps the headers to their value, the values of a header sent several times being combined into a
+// comma-separated list.
+func flattenHeaders(headers http.Header) map[string]interface{} {
+	flattened := make(map[string]interface{}, len(headers))
+	for k, values := range headers {
+		flattened[k] = strings.Join(values, ", ")
+	}
+	return flattened
+}
@@ -0,0 +1,270 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/serverlessworkflow/sdk-go/v3/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPetServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /pets/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":      r.PathValue("id"),
+			"status":  r.URL.Query().Get("status"),
+			"traceId": r.Header.Get("X-Trace-Id"),
+		})
+	})
+	mux.HandleFunc("POST /pets", func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Add("X-Tag", "dog")
+		w.Header().Add("X-Tag", "new")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write(body)
+	})
+	mux.HandleFunc("GET /text", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("hello"))
+	})
+	mux.HandleFunc("GET /missing", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "not here", http.StatusNotFound)
+	})
+	mux.HandleFunc("GET /moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/text", http.StatusFound)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newCallHTTPTask(method, endpoint string, configure func(args *model.HTTPArguments)) *model.CallHTTP {
+	task := &model.CallHTTP{
+		Call: "http",
+		With: model.HTTPArguments{
+			Method:   method,
+			Endpoint: model.NewEndpoint(endpoint),
+		},
+	}
+	if configure != nil {
+		configure(&task.With)
+	}
+	return task
+}
+
+func TestCallHTTPTaskRunner_Run(t *testing.T) {
+	server := newPetServer(t)
+
+	t.Run("GET with expressions in headers, query and URI template", func(t *testing.T) {
+		task := newCallHTTPTask("get", server.URL+"/pets/{petId}", func(args *model.HTTPArguments) {
+			args.Endpoint = &model.Endpoint{URITemplate: &model.LiteralUriTemplate{Value: server.URL + "/pets/{petId}"}}
+			args.Headers = map[string]string{"X-Trace-Id": "${ .trace }"}
+			args.Query = map[string]interface{}{"status": "${ .status }"}
+		})
+		runner, err := NewCallHttpRunner("getPet", task)
+		assert.NoError(t, err)
+
+		output, err := runner.Run(map[string]interface{}{"petId": 42, "trace": "abc", "status": "available"}, newTaskSupport())
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"id": "42", "status": "available", "traceId": "abc"}, output)
+	})
+
+	t.Run("POST with evaluated JSON body and response output", func(t *testing.T) {
+		task := newCallHTTPTask("post", server.URL+"/pets", func(args *model.HTTPArguments) {
+			args.Body = json.RawMessage(`{"name": "${ .name }", "tags": ["dog"]}`)
+			args.Output = "response"
+		})
+		runner, err := NewCallHttpRunner("addPet", task)
+		assert.NoError(t, err)
+
+		output, err := runner.Run(map[string]interface{}{"name": "Rex"}, newTaskSupport())
+		assert.NoError(t, err)
+		response := output.(map[string]interface{})
+		assert.Equal(t, http.StatusCreated, response["statusCode"])
+		assert.Equal(t, map[string]interface{}{"name": "Rex", "tags": []interface{}{"dog"}}, response["content"])
+		assert.Equal(t, "POST", response["request"].(map[string]interface{})["method"])
+		assert.Equal(t, "application/json", response["headers"].(map[string]interface{})["Content-Type"])
+		assert.Equal(t, "dog, new", response["headers"].(map[string]interface{})["X-Tag"])
+	})
+
+	t.Run("raw output is base64 encoded", func(t *testing.T) {
+		runner, err := NewCallHttpRunner("raw", newCallHTTPTask("get", server.URL+"/text", func(args *model.HTTPArguments) {
+			args.Output = "raw"
+		}))
+		assert.NoError(t, err)
+
+		output, err := runner.Run(nil, newTaskSupport())
+		assert.NoError(t, err)
+		assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("hello")), output)
+	})
+
+	t.Run("non-2xx surfaces as communication error with status", func(t *testing.T) {
+		runner, err := NewCallHttpRunner("missing", newCallHTTPTask("get", server.URL+"/missing", nil))
+		assert.NoError(t, err)
+
+		_, err = runner.Run(nil, newTaskSupport())
+		assert.Error(t, err)
+		assert.True(t, model.IsErrCommunication(err))
+		assert.Equal(t, http.StatusNotFound, model.AsError(err).Status)
+	})
+
+	t.Run("redirects are errors unless allowed", func(t *testing.T) {
+		runner, err := NewCallHttpRunner("moved", newCallHTTPTask("get", server.URL+"/moved", nil))
+		assert.NoError(t, err)
+		_, err = runner.Run(nil, newTaskSupport())
+		assert.Equal(t, http.StatusFound, model.AsError(err).Status)
+
+		runner, err = NewCallHttpRunner("moved", newCallHTTPTask("get", server.URL+"/moved", func(args *model.HTTPArguments) {
+			args.Redirect = true
+		}))
+		assert.NoError(t, err)
+		output, err := runner.Run(nil, newTaskSupport())
+		assert.NoError(t, err)
+		assert.Equal(t, "hello", output)
+	})
+}
+
+func TestCallHTTPTaskRunner_Workflow(t *testing.T) {
+	server := newPetServer(t)
+	workflow, err := parser.FromFile("./testdata/call_http_get.yaml")
+	assert.NoError(t, err)
+
+	runner, err := NewDefaultRunner(workflow, WithHTTPClient(server.Client()))
+	assert.NoError(t, err)
+	output, err := runner.Run(map[string]interface{}{"baseUrl": server.URL, "petId": "7"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"petId": "7"}, output)
+}
+
+func TestCallHTTPTaskRunner_Authentication(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		switch {
+		case !ok || password != "secret":
+			w.WriteHeader(http.StatusUnauthorized)
+		case username != "admin":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id": 1}`))
+		}
+	}))
+	defer server.Close()
+
+	workflow, err := parser.FromFile("./testdata/call_http_authentication.yaml")
+	assert.NoError(t, err)
+
+	t.Run("referenced basic policy with expression", func(t *testing.T) {
+		runner, err := NewDefaultRunner(workflow)
+		assert.NoError(t, err)
+		output, err := runner.Run(map[string]interface{}{"baseUrl": server.URL, "password": "secret"})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"id": float64(1)}, output)
+	})
+
+	t.Run("401 is an authentication error", func(t *testing.T) {
+		runner, err := NewDefaultRunner(workflow)
+		assert.NoError(t, err)
+		_, err = runner.Run(map[string]interface{}{"baseUrl": server.URL, "password": "wrong"})
+		assert.True(t, model.IsErrAuthentication(err))
+		assert.Equal(t, http.StatusUnauthorized, model.AsError(err).Status)
+	})
+
+	t.Run("403 is an authorization error", func(t *testing.T) {
+		task := newCallHTTPTask("get", server.URL, func(args *model.HTTPArguments) {
+			args.Endpoint = &model.Endpoint{EndpointConfig: &model.EndpointConfiguration{
+				URI: &model.LiteralUri{Value: server.URL},
+				Authentication: &model.ReferenceableAuthenticationPolicy{
+					AuthenticationPolicy: model.NewBasicAuth("guest", "secret"),
+				},
+			}}
+		})
+		runner, err := NewCallHttpRunner("forbidden", task)
+		assert.NoError(t, err)
+		_, err = runner.Run(nil, newTaskSupport())
+		assert.True(t, model.IsErrAuthorization(err))
+		assert.Equal(t, http.StatusForbidden, model.AsError(err).Status)
+	})
+
+	t.Run("unknown policy reference is a configuration error", func(t *testing.T) {
+		ref := "missing"
+		task := newCallHTTPTask("get", server.URL, func(args *model.HTTPArguments) {
+			args.Endpoint = &model.Endpoint{EndpointConfig: &model.EndpointConfiguration{
+				URI:            &model.LiteralUri{Value: server.URL},
+				Authentication: &model.ReferenceableAuthenticationPolicy{Use: &ref},
+			}}
+		})
+		runner, err := NewCallHttpRunner("unknown", task)
+		assert.NoError(t, err)
+		_, err = runner.Run(nil, newTaskSupport(withWorkflow(workflow)))
+		assert.True(t, model.IsErrConfiguration(err))
+	})
+}
+
+func TestCallHTTPTaskRunner_OAuth2(t *testing.T) {
+	var issued int
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		if r.PostForm.Get("client_secret") != "s3cr3t" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		issued++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token": "abc", "expires_in": 3600}`))
+	})
+	mux.HandleFunc("GET /pets/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer abc" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "` + r.PathValue("id") + `"}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	workflow, err := parser.FromFile("./testdata/call_http_oauth2.yaml")
+	assert.NoError(t, err)
+	workflow.Use.Authentications["petStoreAuth"].OAuth2.Properties.Authority = &model.LiteralUri{Value: server.URL}
+
+	t.Run("token is acquired once and shared by the tasks", func(t *testing.T) {
+		runner, err := NewDefaultRunner(workflow, WithHTTPClient(server.Client()))
+		assert.NoError(t, err)
+		output, err := runner.Run(map[string]interface{}{"baseUrl": server.URL, "clientSecret": "s3cr3t"})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"id": "2"}, output)
+		assert.Equal(t, 1, issued)
+	})
+
+	t.Run("refused token request is an authentication error", func(t *testing.T) {
+		runner, err := NewDefaultRunner(workflow, WithHTTPClient(server.Client()))
+		assert.NoError(t, err)
+		_, err = runner.Run(map[string]interface{}{"baseUrl": server.URL, "clientSecret": "wrong"})
+		assert.True(t, model.IsErrAuthentication(err))
+	})
+}
@@ -0,0 +1,152 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v3/impl/expr"
+	"github.com/serverlessworkflow/sdk-go/v3/impl/utils"
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+)
+
+const (
+	openAPIInPath   = "path"
+	openAPIInQuery  = "query"
+	openAPIInHeader = "header"
+	openAPIInCookie = "cookie"
+)
+
+type CallOpenAPITaskRunner struct {
+	Task     *model.CallOpenAPI
+	TaskName string
+}
+
+func NewCallOpenAPITaskRunner(taskName string, task *model.CallOpenAPI) (*CallOpenAPITaskRunner, error) {
+	if task == nil || task.With.Document == nil || task.With.OperationID == "" {
+		return nil, model.NewErrValidation(fmt.Errorf("invalid OpenAPI call task %s", taskName), taskName)
+	}
+	return &CallOpenAPITaskRunner{Task: task, TaskName: taskName}, nil
+}
+
+// Run loads the OpenAPI document, looks up the operation and sends it through the HTTP call machinery.
+// The parameters are sent where the operation declares them: in the path, query, headers or cookies. On operations
+// accepting a request body, the parameters the operation does not declare make up the JSON body.
+func (o *CallOpenAPITaskRunner) Run(input interface{}, taskSupport TaskSupport) (interface{}, error) {
+	source, location, err := loadExternalResource(o.Task.With.Document, input, o.TaskName, taskSupport)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := parseOpenAPIDocument(source)
+	if err != nil {
+		return nil, model.NewErrConfiguration(err, o.TaskName)
+	}
+	operation, err := doc.findOperation(o.Task.With.OperationID)
+	if err != nil {
+		return nil, model.NewErrConfiguration(err, o.TaskName)
+	}
+
+	request, err := o.evaluateRequest(operation, location, input, taskSupport)
+	if err != nil {
+		return nil, err
+	}
+	client, err := newAuthenticatedHTTPClient(o.Task.With.Authentication, input, o.TaskName, taskSupport)
+	if err != nil {
+		return nil, err
+	}
+	request.Authenticated = o.Task.With.Authentication != nil
+	return doHTTPCall(taskSupport.GetContext(), client, request, taskSupport.GetTaskReference())
+}
+
+func (o *CallOpenAPITaskRunner) GetTaskName() string {
+	return o.TaskName
+}
+
+// evaluateRequest evaluates the task parameters and distributes them according to the operation declaration.
+// Missing required parameters are reported before any request is sent.
+func (o *CallOpenAPITaskRunner) evaluateRequest(operation *openAPIOperationRef, location *url.URL, input interface{}, taskSupport TaskSupport) (*httpCallRequest, error) {
+	params := map[string]interface{}{}
+	if len(o.Task.With.Parameters) > 0 {
+		evaluated, err := expr.TraverseAndEvaluate(utils.DeepClone(o.Task.With.Parameters), input, taskSupport.GetContext())
+		if err != nil {
+			return nil, model.NewErrExpression(err, o.TaskName)
+		}
+		params = evaluated.(map[string]interface{})
+	}
+
+	request := &httpCallRequest{
+		Method:   operation.Method,
+		Output:   o.Task.With.Output,
+		Redirect: o.Task.With.Redirect,
+		Headers:  map[string]string{},
+		Query:    map[string]interface{}{},
+	}
+	path := operation.Path
+	var cookies []string
+	var missing []string
+	declared := map[string]bool{}
+	for _, param := range operation.Parameters {
+		declared[param.Name] = true
+		value, ok := params[param.Name]
+		if !ok || value == nil {
+			if param.Required || param.In == openAPIInPath {
+				missing = append(missing, param.Name)
+			}
+			continue
+		}
+		switch param.In {
+		case openAPIInPath:
+			path = strings.ReplaceAll(path, "{"+param.Name+"}", url.PathEscape(fmt.Sprintf("%v", value)))
+		case openAPIInQuery:
+			request.Query[param.Name] = value
+		case openAPIInHeader:
+			request.Headers[param.Name] = fmt.Sprintf("%v", value)
+		case openAPIInCookie:
+			cookies = append(cookies, fmt.Sprintf("%s=%v", param.Name, value))
+		}
+	}
+	if len(cookies) > 0 {
+		request.Headers["Cookie"] = strings.Join(cookies, "; ")
+	}
+
+	if operation.RequestBody != nil {
+		body := map[string]interface{}{}
+		for name, value := range params {
+			if !declared[name] {
+				body[name] = value
+			}
+		}
+		if len(body) > 0 {
+			request.Body = body
+		} else if operation.RequestBody.Required {
+			missing = append(missing, "body")
+		}
+	}
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, model.NewErrValidation(fmt.Errorf("missing required parameters %v for operation '%s'", missing, o.Task.With.OperationID), o.TaskName)
+	}
+
+	server, err := operation.serverURL(location)
+	if err != nil {
+		return nil, model.NewErrConfiguration(err, o.TaskName)
+	}
+	request.URI = server + path
+	return request, nil
+}
@@ -0,0 +1,142 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/serverlessworkflow/sdk-go/v3/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+// newPetStoreAPI serves the pet store OpenAPI document along with the API it describes, counting the API calls.
+func newPetStoreAPI(t *testing.T, calls *int) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.Handle("GET /openapi/", http.StripPrefix("/openapi/", http.FileServer(http.Dir("./testdata/openapi"))))
+	mux.HandleFunc("GET /api/pets/{id}", func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":      r.PathValue("id"),
+			"status":  r.URL.Query().Get("status"),
+			"traceId": r.Header.Get("X-Trace-Id"),
+		})
+	})
+	mux.HandleFunc("POST /api/pets", func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write(body)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newCallOpenAPITask(document, operationID string, parameters map[string]interface{}) *model.CallOpenAPI {
+	return &model.CallOpenAPI{
+		Call: "openapi",
+		With: model.OpenAPIArguments{
+			Document:    &model.ExternalResource{Endpoint: model.NewEndpoint(document)},
+			OperationID: operationID,
+			Parameters:  parameters,
+		},
+	}
+}
+
+func TestCallOpenAPITaskRunner_Workflow(t *testing.T) {
+	var calls int
+	server := newPetStoreAPI(t, &calls)
+	workflow, err := parser.FromFile("./testdata/call_openapi.yaml")
+	assert.NoError(t, err)
+
+	runner, err := NewDefaultRunner(workflow, WithHTTPClient(server.Client()))
+	assert.NoError(t, err)
+	output, err := runner.Run(map[string]interface{}{"baseUrl": server.URL, "petId": 7})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"id": "7", "status": "available", "traceId": "abc"}, output)
+	assert.Equal(t, 1, calls)
+}
+
+func TestCallOpenAPITaskRunner_Run(t *testing.T) {
+	var calls int
+	server := newPetStoreAPI(t, &calls)
+	document := server.URL + "/openapi/petstore.yaml"
+
+	t.Run("undeclared parameters make up the request body", func(t *testing.T) {
+		runner, err := NewCallOpenAPITaskRunner("addPet", newCallOpenAPITask(document, "addPet",
+			map[string]interface{}{"name": "${ .name }", "tags": []interface{}{"dog"}}))
+		assert.NoError(t, err)
+		output, err := runner.Run(map[string]interface{}{"name": "Rex"}, newTaskSupport())
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"name": "Rex", "tags": []interface{}{"dog"}}, output)
+	})
+
+	t.Run("missing required parameters fail before the request", func(t *testing.T) {
+		calls = 0
+		runner, err := NewCallOpenAPITaskRunner("findPet", newCallOpenAPITask(document, "getPetById", map[string]interface{}{"status": "sold"}))
+		assert.NoError(t, err)
+		_, err = runner.Run(nil, newTaskSupport())
+		assert.True(t, model.IsErrValidation(err))
+		assert.ErrorContains(t, err, "[X-Trace-Id petId]")
+
+		runner, err = NewCallOpenAPITaskRunner("addPet", newCallOpenAPITask(document, "addPet", nil))
+		assert.NoError(t, err)
+		_, err = runner.Run(nil, newTaskSupport())
+		assert.True(t, model.IsErrValidation(err))
+		assert.Equal(t, 0, calls)
+	})
+
+	t.Run("unknown operation is a configuration error", func(t *testing.T) {
+		runner, err := NewCallOpenAPITaskRunner("unknown", newCallOpenAPITask(document, "deletePet", nil))
+		assert.NoError(t, err)
+		_, err = runner.Run(nil, newTaskSupport())
+		assert.True(t, model.IsErrConfiguration(err))
+	})
+
+	t.Run("local document with an absolute server", func(t *testing.T) {
+		source, err := os.ReadFile("./testdata/openapi/petstore.yaml")
+		assert.NoError(t, err)
+		path := filepath.Join(t.TempDir(), "petstore.yaml")
+		assert.NoError(t, os.WriteFile(path, []byte(strings.Replace(string(source), "url: /{basePath}", "url: "+server.URL+"/{basePath}", 1)), 0o600))
+
+		runner, err := NewCallOpenAPITaskRunner("findPet", newCallOpenAPITask(fileURI(path), "getPetById",
+			map[string]interface{}{"petId": "a b", "X-Trace-Id": "xyz"}))
+		assert.NoError(t, err)
+		output, err := runner.Run(nil, newTaskSupport())
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"id": "a b", "status": "", "traceId": "xyz"}, output)
+	})
+
+	t.Run("local document with a relative server is a configuration error", func(t *testing.T) {
+		path, err := filepath.Abs("./testdata/openapi/petstore.yaml")
+		assert.NoError(t, err)
+		runner, err := NewCallOpenAPITaskRunner("findPet", newCallOpenAPITask(fileURI(path), "getPetById",
+			map[string]interface{}{"petId": 1, "X-Trace-Id": "xyz"}))
+		assert.NoError(t, err)
+		_, err = runner.Run(nil, newTaskSupport())
+		assert.True(t, model.IsErrConfiguration(err))
+	})
+}
@@ -38,8 +38,26 @@ func NewTaskRunner(taskName string, task model.Task, workflowDef *model.Workflow
 		return NewForTaskRunner(taskName, t)
 	case *model.CallHTTP:
 		return NewCallHttpRunner(taskName, t)
+	case *model.CallOpenAPI:
+		return NewCallOpenAPITaskRunner(taskName, t)
+	case *model.CallGRPC:
+		return NewCallGRPCTaskRunner(taskName, t)
+	case *model.CallAsyncAPI:
+		return NewCallAsyncAPITaskRunner(taskName, t)
 	case *model.ForkTask:
 		return NewForkTaskRunner(taskName, t, workflowDef)
+	case *model.TryTask:
+		return NewTryTaskRunner(taskName, t)
+	case *model.WaitTask:
+		return NewWaitTaskRunner(taskName, t)
+	case *model.EmitTask:
+		return NewEmitTaskRunner(taskName, t)
+	case *model.ListenTask:
+		return NewListenTaskRunner(taskName, t)
+	case *model.RunTask:
+		return NewRunTaskRunner(taskName, t)
+	case *model.CallFunction:
+		return NewCallFunctionTaskRunner(taskName, t, workflowDef)
 	default:
 		return nil, fmt.Errorf("unsupported task type '%T' for task '%s'", t, taskName)
 	}
@@ -73,14 +91,25 @@ func (d *DoTaskRunner) runTasks(input interface{}, taskSupport TaskSupport) (out
 		return output, nil
 	}
 
+	tracker := trackerOf(taskSupport)
 	idx := 0
-	currentTask := (*d.TaskList)[idx]
+	if frame := tracker.pushFrame(); frame != nil {
+		idx, input, output = frame.Index, frame.Input, frame.Input
+	}
+	defer tracker.popFrame()
+	currentTask := d.taskAt(idx)
 
 	for currentTask != nil {
-		if err = taskSupport.SetTaskDef(currentTask); err != nil {
-			return nil, err
+		tracker.setFrame(idx, input)
+		// deadlines and cancellations are observed at every task boundary
+		if ctxErr := taskSupport.GetContext().Err(); ctxErr != nil {
+			return output, newContextErr(ctxErr, currentTask.Key)
+		}
+		// so are suspensions, until the instance is resumed
+		if ctxErr := taskSupport.AwaitResume(); ctxErr != nil {
+			return output, newContextErr(ctxErr, currentTask.Key)
 		}
-		if err = taskSupport.SetTaskReferenceFromName(currentTask.Key); err != nil {
+		if err = d.enterTask(taskSupport, currentTask); err != nil {
 			return nil, err
 		}
 
@@ -91,22 +120,42 @@ func (d *DoTaskRunner) runTasks(input interface{}, taskSupport TaskSupport) (out
 			continue
 		}
 
+		extensions, err := taskExtensions(currentTask, input, taskSupport)
+		if err != nil {
+			return output, err
+		}
+		if len(extensions) > 0 {
+			if err = runExtensions(extensions, false, input, taskSupport); err != nil {
+				return output, err
+			}
+			// the extension tasks moved the current task, get back to the extended one
+			if err = d.enterTask(taskSupport, currentTask); err != nil {
+				return nil, err
+			}
+		}
+
 		taskSupport.SetTaskStatus(currentTask.Key, ctx.PendingStatus)
 
 		// Check if this task is a SwitchTask and handle it
 		if switchTask, ok := currentTask.Task.(*model.SwitchTask); ok {
 			flowDirective, err := d.evaluateSwitchTask(input, taskSupport, currentTask.Key, switchTask)
 			if err != nil {
-				taskSupport.SetTaskStatus(currentTask.Key, ctx.FaultedStatus)
+				taskSupport.SetTaskFaulted(currentTask.Key, err)
+				return output, err
+			}
+			taskSupport.SetTaskCompleted(currentTask.Key, input)
+			if err = runExtensions(extensions, true, input, taskSupport); err != nil {
 				return output, err
 			}
-			taskSupport.SetTaskStatus(currentTask.Key, ctx.CompletedStatus)
 
 			// Process FlowDirective: update idx/currentTask accordingly
 			idx, currentTask = d.TaskList.KeyAndIndex(flowDirective.Value)
 			if currentTask == nil {
 				return nil, fmt.Errorf("flow directive target '%s' not found", flowDirective.Value)
 			}
+			if err = d.checkpoint(tracker, idx, input); err != nil {
+				return output, err
+			}
 			continue
 		}
 
@@ -117,18 +166,46 @@ func (d *DoTaskRunner) runTasks(input interface{}, taskSupport TaskSupport) (out
 
 		taskSupport.SetTaskStatus(currentTask.Key, ctx.RunningStatus)
 		if output, err = d.runTask(input, taskSupport, runner, currentTask.Task.GetBase()); err != nil {
-			taskSupport.SetTaskStatus(currentTask.Key, ctx.FaultedStatus)
+			taskSupport.SetTaskFaulted(currentTask.Key, err)
 			return output, err
 		}
 
-		taskSupport.SetTaskStatus(currentTask.Key, ctx.CompletedStatus)
+		taskSupport.SetTaskCompleted(currentTask.Key, output)
+		if err = runExtensions(extensions, true, output, taskSupport); err != nil {
+			return output, err
+		}
 		input = utils.DeepCloneValue(output)
 		idx, currentTask = d.TaskList.Next(idx)
+		if err = d.checkpoint(tracker, idx, input); err != nil {
+			return output, err
+		}
 	}
 
 	return output, nil
 }
 
+// taskAt returns the task at the index, nil past the end of the list, e.g. when resuming a list that was completed.
+func (d *DoTaskRunner) taskAt(idx int) *model.TaskItem {
+	if idx < 0 || idx >= len(*d.TaskList) {
+		return nil
+	}
+	return (*d.TaskList)[idx]
+}
+
+// checkpoint persists the instance once a task completed, the next one being the task at the index.
+func (d *DoTaskRunner) checkpoint(tracker executionTracker, idx int, input interface{}) error {
+	tracker.setFrame(idx, input)
+	return tracker.checkpoint()
+}
+
+// enterTask makes the task the current one of the workflow context.
+func (d *DoTaskRunner) enterTask(taskSupport TaskSupport, task *model.TaskItem) error {
+	if err := taskSupport.SetTaskDef(task); err != nil {
+		return err
+	}
+	return taskSupport.SetTaskReferenceFromName(task.Key)
+}
+
 func (d *DoTaskRunner) shouldRunTask(input interface{}, taskSupport TaskSupport, task *model.TaskItem) (bool, error) {
 	if task.GetBase().If != nil {
 		output, err := expr.TraverseAndEvaluateBool(task.GetBase().If.String(), input, taskSupport.GetContext())
@@ -180,10 +257,16 @@ func (d *DoTaskRunner) runTask(input interface{}, taskSupport TaskSupport, runne
 		}
 	}
 
-	output, err = runner.Run(input, taskSupport)
+	taskSupport, cancel, err := withTimeout(task.Timeout, taskSupport, taskName)
 	if err != nil {
 		return nil, err
 	}
+	defer cancel()
+
+	output, err = runner.Run(input, taskSupport)
+	if err != nil {
+		return nil, timeoutErr(err, taskSupport.GetContext(), taskName)
+	}
 
 	taskSupport.SetTaskRawOutput(output)
 
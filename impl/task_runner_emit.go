@@ -0,0 +1,131 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/serverlessworkflow/sdk-go/v3/impl/events"
+	"github.com/serverlessworkflow/sdk-go/v3/impl/expr"
+	"github.com/serverlessworkflow/sdk-go/v3/impl/utils"
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+)
+
+func NewEmitTaskRunner(taskName string, task *model.EmitTask) (*EmitTaskRunner, error) {
+	if task == nil || task.Emit.Event.With == nil {
+		return nil, model.NewErrValidation(fmt.Errorf("invalid Emit task %s", taskName), taskName)
+	}
+	return &EmitTaskRunner{
+		Task:     task,
+		TaskName: taskName,
+	}, nil
+}
+
+type EmitTaskRunner struct {
+	Task     *model.EmitTask
+	TaskName string
+}
+
+// Run evaluates the event properties, completes the missing required attributes and publishes the event.
+// As the specification mandates, the task output is its input.
+func (e *EmitTaskRunner) Run(input interface{}, taskSupport TaskSupport) (interface{}, error) {
+	publisher := configOf(taskSupport).GetEventPublisher()
+	if publisher == nil {
+		return nil, model.NewErrConfiguration(fmt.Errorf("no event publisher configured to emit events from task %s", e.TaskName), e.TaskName)
+	}
+
+	event, err := e.buildEvent(input, taskSupport)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = publisher.Publish(taskSupport.GetContext(), event); err != nil {
+		if ctxErr := taskSupport.GetContext().Err(); ctxErr != nil {
+			return nil, newContextErr(ctxErr, e.TaskName)
+		}
+		return nil, model.NewErrCommunication(fmt.Errorf("failed to publish event %s: %w", event.ID, err), e.TaskName)
+	}
+	return input, nil
+}
+
+func (e *EmitTaskRunner) buildEvent(input interface{}, taskSupport TaskSupport) (*events.Event, error) {
+	evaluated, err := expr.TraverseAndEvaluate(eventPropertiesToMap(e.Task.Emit.Event.With), input, taskSupport.GetContext())
+	if err != nil {
+		return nil, model.NewErrExpression(err, e.TaskName)
+	}
+	attributes, ok := evaluated.(map[string]interface{})
+	if !ok {
+		return nil, model.NewErrExpression(fmt.Errorf("event properties must evaluate to an object, got %T", evaluated), e.TaskName)
+	}
+
+	event, err := events.FromAttributes(attributes)
+	if err != nil {
+		return nil, model.NewErrExpression(err, e.TaskName)
+	}
+	if event.ID == "" {
+		event.ID = uuid.NewString()
+	}
+	if event.Time.IsZero() {
+		event.Time = configOf(taskSupport).GetClock().Now()
+	}
+	if event.Source == "" {
+		event.Source = defaultEventSource(taskSupport.GetWorkflowDef())
+	}
+	if event.SpecVersion == "" {
+		event.SpecVersion = events.SpecVersion
+	}
+	if err = event.Validate(); err != nil {
+		return nil, model.NewErrValidation(err, e.TaskName)
+	}
+	return event, nil
+}
+
+func (e *EmitTaskRunner) GetTaskName() string {
+	return e.TaskName
+}
+
+// eventPropertiesToMap flattens the event properties, including `data` and the extension attributes held in
+// Additional, into a fresh object that can be evaluated without altering the task definition.
+func eventPropertiesToMap(properties *model.EventProperties) map[string]interface{} {
+	attributes := utils.DeepClone(properties.Additional)
+	set := func(name, value string) {
+		if value != "" {
+			attributes[name] = value
+		}
+	}
+	set("id", properties.ID)
+	set("type", properties.Type)
+	set("subject", properties.Subject)
+	set("datacontenttype", properties.DataContentType)
+	if properties.Source != nil {
+		set("source", properties.Source.String())
+	}
+	if properties.Time != nil {
+		set("time", properties.Time.String())
+	}
+	if properties.DataSchema != nil {
+		set("dataschema", properties.DataSchema.String())
+	}
+	return attributes
+}
+
+// defaultEventSource identifies the emitting workflow by its namespace and name.
+func defaultEventSource(workflow *model.Workflow) string {
+	if workflow == nil {
+		return "/"
+	}
+	return fmt.Sprintf("/%s/%s", workflow.Document.Namespace, workflow.Document.Name)
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v3/impl/events"
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/serverlessworkflow/sdk-go/v3/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmitTaskRunner_Run(t *testing.T) {
+	workflow, err := parser.FromFile("./testdata/emit_event.yaml")
+	assert.NoError(t, err)
+
+	t.Run("evaluates the properties and publishes the event", func(t *testing.T) {
+		publisher := events.NewMemoryPublisher()
+		clock := newFakeClock()
+		runner, err := NewDefaultRunner(workflow, WithEventPublisher(publisher), WithClock(clock))
+		assert.NoError(t, err)
+
+		input := map[string]interface{}{"orderId": "42", "tenant": "acme"}
+		output, err := runner.Run(input)
+		assert.NoError(t, err)
+		assert.Equal(t, input, output)
+
+		published := publisher.Events()
+		assert.Len(t, published, 1)
+		event := published[0]
+		assert.NotEmpty(t, event.ID)
+		assert.Equal(t, "https://petstore.com", event.Source)
+		assert.Equal(t, "com.petstore.order.placed.v1", event.Type)
+		assert.Equal(t, events.SpecVersion, event.SpecVersion)
+		assert.Equal(t, "order-42", event.Subject)
+		assert.Equal(t, clock.Now(), event.Time)
+		assert.Equal(t, map[string]interface{}{"orderId": "42", "quantity": float64(2)}, event.Data)
+		assert.Equal(t, map[string]interface{}{"tenant": "acme"}, event.Extensions)
+	})
+
+	t.Run("missing source is derived from the workflow", func(t *testing.T) {
+		publisher := events.NewMemoryPublisher()
+		task := &model.EmitTask{Emit: model.EmitTaskConfiguration{Event: model.EmitEventDefinition{
+			With: &model.EventProperties{ID: "fixed", Type: "com.example.ping.v1"},
+		}}}
+		runner, err := NewEmitTaskRunner("ping", task)
+		assert.NoError(t, err)
+
+		_, err = runner.Run(nil, newTaskSupport(withWorkflow(workflow), withEventPublisher(publisher)))
+		assert.NoError(t, err)
+		assert.Equal(t, "fixed", publisher.Events()[0].ID)
+		assert.Equal(t, "/test/emit-event", publisher.Events()[0].Source)
+	})
+
+	t.Run("missing type is a validation error", func(t *testing.T) {
+		task := &model.EmitTask{Emit: model.EmitTaskConfiguration{Event: model.EmitEventDefinition{
+			With: &model.EventProperties{Subject: "no type"},
+		}}}
+		runner, err := NewEmitTaskRunner("invalid", task)
+		assert.NoError(t, err)
+		_, err = runner.Run(nil, newTaskSupport(withEventPublisher(events.NewMemoryPublisher())))
+		assert.True(t, model.IsErrValidation(err))
+	})
+
+	t.Run("no publisher is a configuration error", func(t *testing.T) {
+		runner, err := NewDefaultRunner(workflow)
+		assert.NoError(t, err)
+		_, err = runner.Run(map[string]interface{}{"orderId": "42"})
+		assert.True(t, model.IsErrConfiguration(err))
+	})
+}
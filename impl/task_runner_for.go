@@ -52,24 +52,31 @@ type ForTaskRunner struct {
 }
 
 func (f *ForTaskRunner) Run(input interface{}, taskSupport TaskSupport) (interface{}, error) {
+	each, at := f.forVarNames()
 	defer func() {
 		// clear local variables
-		taskSupport.RemoveLocalExprVars(f.Task.For.Each, f.Task.For.At)
+		taskSupport.RemoveLocalExprVars(each, at)
 	}()
-	f.sanitizeFor()
 	in, err := expr.TraverseAndEvaluate(f.Task.For.In, input, taskSupport.GetContext())
 	if err != nil {
 		return nil, err
 	}
 
-	forOutput := input
+	start, forOutput := 0, input
+	// when resuming, the iterations that completed are skipped, their output is the one of the frame
+	tracker := trackerOf(taskSupport)
+	if frame := tracker.pushFrame(); frame != nil {
+		start, forOutput = frame.Index, frame.Input
+	}
+	defer tracker.popFrame()
 	rv := reflect.ValueOf(in)
 	switch rv.Kind() {
 	case reflect.Slice, reflect.Array:
-		for i := 0; i < rv.Len(); i++ {
+		for i := start; i < rv.Len(); i++ {
 			item := rv.Index(i).Interface()
+			tracker.setFrame(i, forOutput)
 
-			if forOutput, err = f.processForItem(i, item, taskSupport, forOutput); err != nil {
+			if forOutput, err = f.processForItem(each, at, i, item, taskSupport, forOutput); err != nil {
 				return nil, err
 			}
 			if f.Task.While != "" {
@@ -85,7 +92,8 @@ func (f *ForTaskRunner) Run(input interface{}, taskSupport TaskSupport) (interfa
 	case reflect.Invalid:
 		return input, nil
 	default:
-		if forOutput, err = f.processForItem(0, in, taskSupport, forOutput); err != nil {
+		tracker.setFrame(0, forOutput)
+		if forOutput, err = f.processForItem(each, at, 0, in, taskSupport, forOutput); err != nil {
 			return nil, err
 		}
 	}
@@ -93,10 +101,10 @@ func (f *ForTaskRunner) Run(input interface{}, taskSupport TaskSupport) (interfa
 	return forOutput, nil
 }
 
-func (f *ForTaskRunner) processForItem(idx int, item interface{}, taskSupport TaskSupport, forOutput interface{}) (interface{}, error) {
+func (f *ForTaskRunner) processForItem(each, at string, idx int, item interface{}, taskSupport TaskSupport, forOutput interface{}) (interface{}, error) {
 	forVars := map[string]interface{}{
-		f.Task.For.At:   idx,
-		f.Task.For.Each: item,
+		at:   idx,
+		each: item,
 	}
 	// Instead of Set, we Add since other tasks in this very same context might be adding variables to the context
 	taskSupport.AddLocalExprVars(forVars)
@@ -110,23 +118,26 @@ func (f *ForTaskRunner) processForItem(idx int, item interface{}, taskSupport Ta
 	return forOutput, nil
 }
 
-func (f *ForTaskRunner) sanitizeFor() {
-	f.Task.For.Each = strings.TrimSpace(f.Task.For.Each)
-	f.Task.For.At = strings.TrimSpace(f.Task.For.At)
+// forVarNames returns the names of the item and index variables, leaving the definition untouched since it may be
+// shared with concurrent runs, e.g. in `fork` branches.
+func (f *ForTaskRunner) forVarNames() (each, at string) {
+	each = strings.TrimSpace(f.Task.For.Each)
+	at = strings.TrimSpace(f.Task.For.At)
 
-	if f.Task.For.Each == "" {
-		f.Task.For.Each = forTaskDefaultEach
+	if each == "" {
+		each = forTaskDefaultEach
 	}
-	if f.Task.For.At == "" {
-		f.Task.For.At = forTaskDefaultAt
+	if at == "" {
+		at = forTaskDefaultAt
 	}
 
-	if !strings.HasPrefix(f.Task.For.Each, "$") {
-		f.Task.For.Each = "$" + f.Task.For.Each
+	if !strings.HasPrefix(each, "$") {
+		each = "$" + each
 	}
-	if !strings.HasPrefix(f.Task.For.At, "$") {
-		f.Task.For.At = "$" + f.Task.For.At
+	if !strings.HasPrefix(at, "$") {
+		at = "$" + at
 	}
+	return each, at
 }
 
 func (f *ForTaskRunner) GetTaskName() string {
@@ -107,10 +107,23 @@ func (f ForkTaskRunner) Run(input interface{}, parentSupport TaskSupport) (inter
 			return <-resultCh, nil
 		case err := <-errs:
 			return nil, err
+		case <-parentSupport.GetContext().Done():
+			return nil, newContextErr(parentSupport.GetContext().Err(), f.TaskName)
 		}
 	}
 
-	wg.Wait()
+	finished := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(finished)
+	}()
+	select {
+	case <-finished:
+	case <-parentSupport.GetContext().Done():
+		// branches still running observe the same cancellation and finish on their own
+		return nil, newContextErr(parentSupport.GetContext().Err(), f.TaskName)
+	}
+
 	select {
 	case err := <-errs:
 		return nil, err
@@ -0,0 +1,85 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"fmt"
+
+	"github.com/serverlessworkflow/sdk-go/v3/impl/ctx"
+	"github.com/serverlessworkflow/sdk-go/v3/impl/events"
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+)
+
+func NewListenTaskRunner(taskName string, task *model.ListenTask) (*ListenTaskRunner, error) {
+	if task == nil || task.Listen.To == nil {
+		return nil, model.NewErrValidation(fmt.Errorf("invalid Listen task %s", taskName), taskName)
+	}
+	return &ListenTaskRunner{
+		Task:     task,
+		TaskName: taskName,
+	}, nil
+}
+
+type ListenTaskRunner struct {
+	Task     *model.ListenTask
+	TaskName string
+}
+
+// Run subscribes to the runner's events and blocks, in the waiting status, until the consumption strategy is
+// satisfied. The output is the list of consumed events.
+func (l *ListenTaskRunner) Run(input interface{}, taskSupport TaskSupport) (interface{}, error) {
+	subscriber := configOf(taskSupport).GetEventSubscriber()
+	if subscriber == nil {
+		return nil, model.NewErrConfiguration(fmt.Errorf("no event subscriber configured to listen to events in task %s", l.TaskName), l.TaskName)
+	}
+
+	consumer, err := newEventConsumer(l.Task.Listen.To, input, taskSupport, l.TaskName)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription, err := subscriber.Subscribe(taskSupport.GetContext())
+	if err != nil {
+		if ctxErr := taskSupport.GetContext().Err(); ctxErr != nil {
+			return nil, newContextErr(ctxErr, l.TaskName)
+		}
+		return nil, model.NewErrCommunication(fmt.Errorf("failed to subscribe to events: %w", err), l.TaskName)
+	}
+	defer subscription.Close()
+
+	taskSupport.SetTaskStatus(l.TaskName, ctx.WaitingStatus)
+	for {
+		select {
+		case <-taskSupport.GetContext().Done():
+			return nil, newContextErr(taskSupport.GetContext().Err(), l.TaskName)
+		case event, ok := <-subscription.Events():
+			if !ok {
+				return nil, model.NewErrCommunication(events.ErrSubscriptionClosed, l.TaskName)
+			}
+			done, err := consumer.offer(event, input, taskSupport)
+			if err != nil {
+				return nil, err
+			}
+			if done {
+				taskSupport.SetTaskStatus(l.TaskName, ctx.RunningStatus)
+				return consumer.output(), nil
+			}
+		}
+	}
+}
+
+func (l *ListenTaskRunner) GetTaskName() string {
+	return l.TaskName
+}
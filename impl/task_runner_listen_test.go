@@ -0,0 +1,231 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v3/impl/events"
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/serverlessworkflow/sdk-go/v3/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+type listenResult struct {
+	output interface{}
+	err    error
+}
+
+// startListening runs the task in the background and waits until it is subscribed to the bus.
+func startListening(t *testing.T, bus *events.MemoryBus, run func() (interface{}, error)) <-chan listenResult {
+	result := make(chan listenResult, 1)
+	go func() {
+		output, err := run()
+		result <- listenResult{output: output, err: err}
+	}()
+	assert.Eventually(t, func() bool { return bus.Subscribers() > 0 }, time.Second, time.Millisecond)
+	return result
+}
+
+func publish(t *testing.T, bus *events.MemoryBus, eventType, source string, data map[string]interface{}) {
+	event := &events.Event{ID: eventType, Source: source, Type: eventType, SpecVersion: events.SpecVersion, Data: data}
+	assert.NoError(t, bus.Publish(context.Background(), event))
+}
+
+func newListenTask(strategy *model.EventConsumptionStrategy) *model.ListenTask {
+	return &model.ListenTask{Listen: model.ListenTaskConfiguration{To: strategy}}
+}
+
+func filterOn(eventType string) *model.EventFilter {
+	return &model.EventFilter{With: &model.EventProperties{Type: eventType}}
+}
+
+func TestListenTaskRunner_Workflow(t *testing.T) {
+	workflow, err := parser.FromFile("./testdata/listen_events.yaml")
+	assert.NoError(t, err)
+
+	bus := events.NewMemoryBus()
+	runner, err := NewDefaultRunner(workflow, WithEventSubscriber(bus))
+	assert.NoError(t, err)
+	result := startListening(t, bus, func() (interface{}, error) {
+		return runner.Run(map[string]interface{}{"orderId": "42"})
+	})
+
+	publish(t, bus, "com.petstore.order.paid.v1", "https://shop.petstore.com", map[string]interface{}{"orderId": "7"})
+	publish(t, bus, "com.petstore.order.shipped.v1", "https://shop.petstore.com", map[string]interface{}{"orderId": "42"})
+	publish(t, bus, "com.petstore.order.shipped.v1", "https://warehouse.petstore.com/eu", map[string]interface{}{"orderId": "42"})
+	publish(t, bus, "com.petstore.order.paid.v1", "https://shop.petstore.com", map[string]interface{}{"orderId": "42"})
+
+	res := <-result
+	assert.NoError(t, res.err)
+	assert.Equal(t, []interface{}{"com.petstore.order.shipped.v1", "com.petstore.order.paid.v1"}, res.output)
+	assert.Equal(t, 0, bus.Subscribers())
+}
+
+func TestListenTaskRunner_Run(t *testing.T) {
+	t.Run("one consumes the first matching event", func(t *testing.T) {
+		bus := events.NewMemoryBus()
+		runner, err := NewListenTaskRunner("listen", newListenTask(&model.EventConsumptionStrategy{One: filterOn("com.example.ping.v1")}))
+		assert.NoError(t, err)
+		result := startListening(t, bus, func() (interface{}, error) {
+			return runner.Run(nil, newTaskSupport(withEventSubscriber(bus)))
+		})
+
+		publish(t, bus, "com.example.other.v1", "/test", nil)
+		publish(t, bus, "com.example.ping.v1", "/test", map[string]interface{}{"n": 1})
+
+		res := <-result
+		assert.NoError(t, res.err)
+		consumed := res.output.([]interface{})
+		assert.Len(t, consumed, 1)
+		assert.Equal(t, map[string]interface{}{"n": 1}, consumed[0].(map[string]interface{})["data"])
+	})
+
+	t.Run("any consumes events until the condition holds", func(t *testing.T) {
+		bus := events.NewMemoryBus()
+		runner, err := NewListenTaskRunner("listen", newListenTask(&model.EventConsumptionStrategy{
+			Any:   []*model.EventFilter{filterOn("com.example.ping.v1"), filterOn("com.example.pong.v1")},
+			Until: &model.EventConsumptionUntil{Condition: model.NewExpr("${ length >= 2 }")},
+		}))
+		assert.NoError(t, err)
+		result := startListening(t, bus, func() (interface{}, error) {
+			return runner.Run(nil, newTaskSupport(withEventSubscriber(bus)))
+		})
+
+		publish(t, bus, "com.example.ping.v1", "/test", nil)
+		publish(t, bus, "com.example.other.v1", "/test", nil)
+		publish(t, bus, "com.example.pong.v1", "/test", nil)
+
+		res := <-result
+		assert.NoError(t, res.err)
+		assert.Len(t, res.output, 2)
+	})
+
+	t.Run("any stops when the until strategy is satisfied", func(t *testing.T) {
+		bus := events.NewMemoryBus()
+		runner, err := NewListenTaskRunner("listen", newListenTask(&model.EventConsumptionStrategy{
+			Any:   []*model.EventFilter{filterOn("com.example.ping.v1")},
+			Until: &model.EventConsumptionUntil{Strategy: &model.EventConsumptionStrategy{One: filterOn("com.example.stop.v1")}},
+		}))
+		assert.NoError(t, err)
+		result := startListening(t, bus, func() (interface{}, error) {
+			return runner.Run(nil, newTaskSupport(withEventSubscriber(bus)))
+		})
+
+		publish(t, bus, "com.example.ping.v1", "/test", nil)
+		publish(t, bus, "com.example.ping.v1", "/test", nil)
+		publish(t, bus, "com.example.stop.v1", "/test", nil)
+
+		res := <-result
+		assert.NoError(t, res.err)
+		assert.Len(t, res.output, 2)
+	})
+
+	t.Run("any stops when the until all strategy is satisfied", func(t *testing.T) {
+		bus := events.NewMemoryBus()
+		runner, err := NewListenTaskRunner("listen", newListenTask(&model.EventConsumptionStrategy{
+			Any: []*model.EventFilter{filterOn("com.example.ping.v1")},
+			Until: &model.EventConsumptionUntil{Strategy: &model.EventConsumptionStrategy{
+				All: []*model.EventFilter{filterOn("com.example.stop.v1"), filterOn("com.example.halt.v1")},
+			}},
+		}))
+		assert.NoError(t, err)
+		result := startListening(t, bus, func() (interface{}, error) {
+			return runner.Run(nil, newTaskSupport(withEventSubscriber(bus)))
+		})
+
+		publish(t, bus, "com.example.ping.v1", "/test", nil)
+		publish(t, bus, "com.example.stop.v1", "/test", nil)
+		publish(t, bus, "com.example.ping.v1", "/test", nil)
+		publish(t, bus, "com.example.halt.v1", "/test", nil)
+		publish(t, bus, "com.example.ping.v1", "/test", nil)
+
+		res := <-result
+		assert.NoError(t, res.err)
+		assert.Len(t, res.output, 2)
+	})
+
+	t.Run("until false listens until cancelled", func(t *testing.T) {
+		bus := events.NewMemoryBus()
+		runner, err := NewListenTaskRunner("listen", newListenTask(&model.EventConsumptionStrategy{
+			Any:   []*model.EventFilter{filterOn("com.example.ping.v1")},
+			Until: &model.EventConsumptionUntil{IsDisabled: true},
+		}))
+		assert.NoError(t, err)
+		listenCtx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		result := startListening(t, bus, func() (interface{}, error) {
+			return runner.Run(nil, newTaskSupport(withEventSubscriber(bus), withContext(listenCtx)))
+		})
+
+		for i := 0; i < 3; i++ {
+			publish(t, bus, "com.example.ping.v1", "/test", nil)
+		}
+		select {
+		case res := <-result:
+			t.Fatalf("listening stopped with %v, %v", res.output, res.err)
+		case <-time.After(20 * time.Millisecond):
+		}
+		cancel()
+		res := <-result
+		assert.True(t, model.IsErrRuntime(res.err))
+		assert.ErrorContains(t, res.err, context.Canceled.Error())
+	})
+
+	t.Run("until only applies to any", func(t *testing.T) {
+		runner, err := NewListenTaskRunner("listen", newListenTask(&model.EventConsumptionStrategy{
+			All:   []*model.EventFilter{filterOn("com.example.ping.v1")},
+			Until: &model.EventConsumptionUntil{Condition: model.NewExpr("${ length >= 2 }")},
+		}))
+		assert.NoError(t, err)
+		_, err = runner.Run(nil, newTaskSupport(withEventSubscriber(events.NewMemoryBus())))
+		assert.True(t, model.IsErrValidation(err))
+	})
+
+	t.Run("cancellation stops listening", func(t *testing.T) {
+		bus := events.NewMemoryBus()
+		runner, err := NewListenTaskRunner("listen", newListenTask(&model.EventConsumptionStrategy{One: filterOn("com.example.ping.v1")}))
+		assert.NoError(t, err)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err = runner.Run(nil, newTaskSupport(withEventSubscriber(bus), withContext(ctx)))
+		assert.True(t, model.IsErrTimeout(err))
+		assert.Equal(t, 0, bus.Subscribers())
+	})
+
+	t.Run("no subscriber is a configuration error", func(t *testing.T) {
+		runner, err := NewListenTaskRunner("listen", newListenTask(&model.EventConsumptionStrategy{One: filterOn("com.example.ping.v1")}))
+		assert.NoError(t, err)
+		_, err = runner.Run(nil, newTaskSupport())
+		assert.True(t, model.IsErrConfiguration(err))
+	})
+}
+
+func TestMatchEventValue(t *testing.T) {
+	assert.True(t, matchEventValue("com.example.ping.v1", "com.example.ping.v1"))
+	assert.True(t, matchEventValue(`com\.example\..*`, "com.example.ping.v1"))
+	assert.False(t, matchEventValue("example", "com.example.ping.v1"))
+	assert.False(t, matchEventValue("1", 1))
+	assert.True(t, matchEventValue(float64(2), 2))
+	assert.True(t, matchEventValue(
+		map[string]interface{}{"order": map[string]interface{}{"id": "42"}},
+		map[string]interface{}{"order": map[string]interface{}{"id": "42", "total": 10}, "tenant": "acme"},
+	))
+	assert.False(t, matchEventValue(map[string]interface{}{"id": "42"}, map[string]interface{}{"id": "7"}))
+	assert.False(t, matchEventValue(map[string]interface{}{"id": "42"}, nil))
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+)
+
+func NewRunTaskRunner(taskName string, task *model.RunTask) (*RunTaskRunner, error) {
+	if task == nil {
+		return nil, model.NewErrValidation(fmt.Errorf("invalid Run task %s", taskName), taskName)
+	}
+	runner := &RunTaskRunner{
+		Task:     task,
+		TaskName: taskName,
+	}
+	switch {
+	case task.Run.Shell != nil, task.Run.Workflow != nil:
+	case task.Run.Container != nil:
+		if lifetime := task.Run.Container.Lifetime; lifetime != nil {
+			switch lifetime.Cleanup {
+			case containerCleanupAlways, containerCleanupNever:
+			case containerCleanupEventually:
+				if lifetime.After == nil {
+					return nil, model.NewErrValidation(fmt.Errorf("task %s: eventual container cleanup requires a delay", taskName), taskName)
+				}
+				after, err := lifetime.After.AsTimeDuration()
+				if err != nil {
+					return nil, model.NewErrValidation(fmt.Errorf("invalid container cleanup delay for task %s: %w", taskName, err), taskName)
+				}
+				runner.cleanupAfter = after
+			default:
+				return nil, model.NewErrValidation(fmt.Errorf("task %s: unknown container cleanup policy '%s'", taskName, lifetime.Cleanup), taskName)
+			}
+		}
+	default:
+		return nil, model.NewErrValidation(fmt.Errorf("task %s: only shell, container and workflow processes can be run", taskName), taskName)
+	}
+	return runner, nil
+}
+
+type RunTaskRunner struct {
+	Task     *model.RunTask
+	TaskName string
+	// cleanupAfter is the delay before removing a container eventually cleaned up
+	cleanupAfter time.Duration
+}
+
+// Run executes the process. When the task does not await it, the process is started in the background and the
+// task output is its input.
+func (r *RunTaskRunner) Run(input interface{}, taskSupport TaskSupport) (interface{}, error) {
+	switch {
+	case r.Task.Run.Container != nil:
+		return r.runContainer(input, taskSupport)
+	case r.Task.Run.Workflow != nil:
+		return r.runWorkflow(input, taskSupport)
+	default:
+		return r.runShell(input, taskSupport)
+	}
+}
+
+func (r *RunTaskRunner) GetTaskName() string {
+	return r.TaskName
+}
+
+// await tells whether the task waits for the process to complete, which it does by default.
+func (r *RunTaskRunner) await() bool {
+	return r.Task.Run.Await == nil || *r.Task.Run.Await
+}
+
+// newProcessResult is the output of an awaited process.
+func newProcessResult(code int, stdout, stderr string) map[string]interface{} {
+	return map[string]interface{}{
+		"code":   code,
+		"stdout": stdout,
+		"stderr": stderr,
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v3/impl/expr"
+	"github.com/serverlessworkflow/sdk-go/v3/impl/utils"
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+)
+
+const (
+	containerCleanupAlways     = "always"
+	containerCleanupNever      = "never"
+	containerCleanupEventually = "eventually"
+	defaultPullPolicy          = "ifNotPresent"
+	// containerRemoveTimeout bounds the removal of a container, which may have to stop it first.
+	containerRemoveTimeout = 30 * time.Second
+)
+
+// runContainer runs the container on the runner's ContainerRuntime, then removes it as its lifetime says: right
+// after it exits, after a delay, or never, which is the default. A container still running when the task, or the
+// instance when not awaited, ends is removed whatever its lifetime, like the processes of shell tasks are killed.
+func (r *RunTaskRunner) runContainer(input interface{}, taskSupport TaskSupport) (interface{}, error) {
+	runtime := configOf(taskSupport).GetContainerRuntime()
+	if runtime == nil {
+		return nil, model.NewErrConfiguration(fmt.Errorf("no container runtime configured to run task %s", r.TaskName), r.TaskName)
+	}
+	spec, err := r.evaluateContainer(input, taskSupport)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := runtime.Start(taskSupport.GetContext(), *spec)
+	if err != nil {
+		if ctxErr := taskSupport.GetContext().Err(); ctxErr != nil {
+			return nil, newContextErr(ctxErr, r.TaskName)
+		}
+		return nil, model.NewErrRuntime(fmt.Errorf("failed to start container from image %s: %w", spec.Image, err), r.TaskName)
+	}
+
+	// the cleanup, and the container when not awaited, outlive the task but not the cancellation of the instance
+	lifetime := detachedContext(taskSupport)
+	if !r.await() {
+		go func() {
+			_, _ = runtime.Wait(lifetime, id)
+			if lifetime.Err() != nil {
+				removeContainer(lifetime, runtime, id)
+				return
+			}
+			r.cleanupContainer(lifetime, runtime, id, configOf(taskSupport).GetClock())
+		}()
+		return input, nil
+	}
+
+	result, err := runtime.Wait(taskSupport.GetContext(), id)
+	if ctxErr := taskSupport.GetContext().Err(); ctxErr != nil && err != nil {
+		removeContainer(lifetime, runtime, id)
+		return nil, newContextErr(ctxErr, r.TaskName)
+	}
+	r.cleanupContainer(lifetime, runtime, id, configOf(taskSupport).GetClock())
+	if err != nil {
+		return nil, model.NewErrRuntime(fmt.Errorf("failed to wait for container %s: %w", id, err), r.TaskName)
+	}
+	if result.Code != 0 {
+		return nil, model.NewErrRuntime(fmt.Errorf("container %s exited with code %d: %s", id, result.Code, strings.TrimSpace(result.Stderr)), r.TaskName)
+	}
+	return newProcessResult(result.Code, result.Stdout, result.Stderr), nil
+}
+
+// cleanupContainer removes the container according to its lifetime. Removal failures are not the task's: the
+// container already ran. Once the instance is cancelled, an eventual removal happens right away.
+func (r *RunTaskRunner) cleanupContainer(lifetimeCtx context.Context, runtime ContainerRuntime, id string, clock Clock) {
+	lifetime := r.Task.Run.Container.Lifetime
+	if lifetime == nil {
+		return
+	}
+	switch lifetime.Cleanup {
+	case containerCleanupAlways:
+		removeContainer(lifetimeCtx, runtime, id)
+	case containerCleanupEventually:
+		go func() {
+			_ = sleep(lifetimeCtx, clock, r.cleanupAfter)
+			removeContainer(lifetimeCtx, runtime, id)
+		}()
+	}
+}
+
+// removeContainer removes the container even when the instance is cancelled, within containerRemoveTimeout.
+func removeContainer(ctx context.Context, runtime ContainerRuntime, id string) {
+	removeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), containerRemoveTimeout)
+	defer cancel()
+	_ = runtime.Remove(removeCtx, id)
+}
+
+// evaluateContainer evaluates the runtime expressions of the container definition.
+func (r *RunTaskRunner) evaluateContainer(input interface{}, taskSupport TaskSupport) (*ContainerSpec, error) {
+	container := r.Task.Run.Container
+	properties := map[string]interface{}{
+		"image":   container.Image,
+		"name":    container.Name,
+		"command": container.Command,
+		"stdin":   container.Input,
+		"ports":   utils.DeepClone(container.Ports),
+		"volumes": utils.DeepClone(container.Volumes),
+	}
+	args := make([]interface{}, 0, len(container.Arguments))
+	for _, arg := range container.Arguments {
+		args = append(args, arg)
+	}
+	properties["arguments"] = args
+	env := make(map[string]interface{}, len(container.Environment))
+	for name, value := range container.Environment {
+		env[name] = value
+	}
+	properties["environment"] = env
+
+	evaluated, err := expr.TraverseAndEvaluate(properties, input, taskSupport.GetContext())
+	if err != nil {
+		return nil, model.NewErrExpression(err, r.TaskName)
+	}
+	properties = evaluated.(map[string]interface{})
+
+	spec := &ContainerSpec{
+		Image:       processArgument(properties["image"]),
+		Name:        processArgument(properties["name"]),
+		Command:     processArgument(properties["command"]),
+		Stdin:       processArgument(properties["stdin"]),
+		Environment: map[string]string{},
+		PullPolicy:  container.PullPolicy,
+	}
+	if spec.Image == "" {
+		return nil, model.NewErrValidation(fmt.Errorf("container image must evaluate to a non-empty string"), r.TaskName)
+	}
+	if spec.PullPolicy == "" {
+		spec.PullPolicy = defaultPullPolicy
+	}
+	for _, arg := range properties["arguments"].([]interface{}) {
+		spec.Arguments = append(spec.Arguments, processArgument(arg))
+	}
+	for name, value := range properties["environment"].(map[string]interface{}) {
+		spec.Environment[name] = processArgument(value)
+	}
+	spec.Ports, _ = properties["ports"].(map[string]interface{})
+	spec.Volumes, _ = properties["volumes"].(map[string]interface{})
+	return spec, nil
+}
@@ -0,0 +1,186 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/serverlessworkflow/sdk-go/v3/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func echoContainer(_ context.Context, spec ContainerSpec) (*ContainerResult, error) {
+	if spec.Command == "fail" {
+		return &ContainerResult{Code: 2, Stderr: "no such file\n"}, nil
+	}
+	return &ContainerResult{Stdout: spec.Stdin}, nil
+}
+
+func newContainerTask(command string, lifetime *model.ContainerLifetime, await *bool) *model.RunTask {
+	return &model.RunTask{Run: model.RunTaskConfiguration{
+		Await:     await,
+		Container: &model.Container{Image: "alpine", Command: command, Lifetime: lifetime},
+	}}
+}
+
+func TestRunTaskRunner_Container(t *testing.T) {
+	t.Run("every field is evaluated and handed to the runtime", func(t *testing.T) {
+		workflow, err := parser.FromFile("./testdata/run_container.yaml")
+		assert.NoError(t, err)
+		runtime := NewFakeContainerRuntime(echoContainer)
+		runner, err := NewDefaultRunner(workflow, WithContainerRuntime(runtime))
+		assert.NoError(t, err)
+
+		output, err := runner.Run(map[string]interface{}{"version": "1.2", "picture": "dog.png", "tenant": "acme"})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"code": 0, "stdout": "dog.png", "stderr": ""}, output)
+		assert.Equal(t, []ContainerSpec{{
+			Image:       "registry.petstore.com/resizer:1.2",
+			Name:        "resizer",
+			Command:     "resize",
+			Arguments:   []string{"dog.png", "--width=200"},
+			Environment: map[string]string{"LOG_LEVEL": "debug", "TENANT": "acme"},
+			Ports:       map[string]interface{}{"8080": float64(80)},
+			Volumes:     map[string]interface{}{"/data": "/mnt/data"},
+			Stdin:       "dog.png",
+			PullPolicy:  "always",
+		}}, runtime.Started())
+		assert.Empty(t, runtime.Containers())
+	})
+
+	t.Run("containers are kept by default", func(t *testing.T) {
+		runtime := NewFakeContainerRuntime(echoContainer)
+		runner, err := NewRunTaskRunner("keep", newContainerTask("run", nil, nil))
+		assert.NoError(t, err)
+		_, err = runner.Run(nil, newTaskSupport(withContainerRuntime(runtime)))
+		assert.NoError(t, err)
+		assert.Len(t, runtime.Containers(), 1)
+		assert.Equal(t, defaultPullPolicy, runtime.Started()[0].PullPolicy)
+	})
+
+	t.Run("eventual cleanup waits for the delay", func(t *testing.T) {
+		runtime := NewFakeContainerRuntime(echoContainer)
+		clock := newManualClock()
+		lifetime := &model.ContainerLifetime{Cleanup: "eventually", After: model.NewDurationExpr("PT5M")}
+		runner, err := NewRunTaskRunner("eventually", newContainerTask("run", lifetime, nil))
+		assert.NoError(t, err)
+
+		_, err = runner.Run(nil, newTaskSupport(withContainerRuntime(runtime), withClock(clock)))
+		assert.NoError(t, err)
+		assert.Len(t, runtime.Containers(), 1)
+		clock.advance(t, 5*time.Minute)
+		assert.Eventually(t, func() bool { return len(runtime.Containers()) == 0 }, time.Second, time.Millisecond)
+	})
+
+	t.Run("non-zero exit is a runtime error", func(t *testing.T) {
+		runner, err := NewRunTaskRunner("fail", newContainerTask("fail", nil, nil))
+		assert.NoError(t, err)
+		_, err = runner.Run(nil, newTaskSupport(withContainerRuntime(NewFakeContainerRuntime(echoContainer))))
+		assert.True(t, model.IsErrRuntime(err))
+		assert.Contains(t, err.Error(), "exited with code 2: no such file")
+	})
+
+	t.Run("not awaited container runs in the background", func(t *testing.T) {
+		release := make(chan struct{})
+		runtime := NewFakeContainerRuntime(func(ctx context.Context, spec ContainerSpec) (*ContainerResult, error) {
+			<-release
+			return &ContainerResult{}, nil
+		})
+		await := false
+		runner, err := NewRunTaskRunner("background", newContainerTask("run", &model.ContainerLifetime{Cleanup: "always"}, &await))
+		assert.NoError(t, err)
+
+		input := map[string]interface{}{"id": 1}
+		output, err := runner.Run(input, newTaskSupport(withContainerRuntime(runtime)))
+		assert.NoError(t, err)
+		assert.Equal(t, input, output)
+		assert.Len(t, runtime.Containers(), 1)
+		close(release)
+		assert.Eventually(t, func() bool { return len(runtime.Containers()) == 0 }, time.Second, time.Millisecond)
+	})
+
+	for name, lifetime := range map[string]string{
+		"kept":       "",
+		"eventually": "\n          lifetime:\n            cleanup: eventually\n            after:\n              hours: 1",
+	} {
+		t.Run("not awaited container is removed when the instance is cancelled, "+name, func(t *testing.T) {
+			release := make(chan struct{})
+			defer close(release)
+			runtime := NewFakeContainerRuntime(func(ctx context.Context, spec ContainerSpec) (*ContainerResult, error) {
+				<-release
+				return &ContainerResult{}, nil
+			})
+			workflow, err := parser.FromYAMLSource([]byte(`
+document:
+  dsl: '1.0.0'
+  namespace: test
+  name: run-container-background
+  version: '1.0.0'
+do:
+  - background:
+      run:
+        container:
+          image: alpine
+          pullPolicy: ifNotPresent` + lifetime + `
+        await: false
+  - wait:
+      wait:
+        seconds: 30
+`))
+			assert.NoError(t, err)
+			runner, err := NewDefaultRunner(workflow, WithContainerRuntime(runtime))
+			assert.NoError(t, err)
+			handle, err := runner.Start(context.Background(), nil)
+			assert.NoError(t, err)
+
+			assert.Eventually(t, func() bool { return len(runtime.Started()) == 1 }, time.Second, time.Millisecond)
+			assert.Len(t, runtime.Containers(), 1)
+			assert.NoError(t, handle.Cancel())
+			_, err = handle.Wait(context.Background())
+			assert.Error(t, err)
+			assert.Eventually(t, func() bool { return len(runtime.Containers()) == 0 }, time.Second, time.Millisecond)
+		})
+	}
+
+	t.Run("cancellation stops waiting and removes the container", func(t *testing.T) {
+		runtime := NewFakeContainerRuntime(func(ctx context.Context, spec ContainerSpec) (*ContainerResult, error) {
+			time.Sleep(time.Second)
+			return &ContainerResult{}, nil
+		})
+		runner, err := NewRunTaskRunner("slow", newContainerTask("run", nil, nil))
+		assert.NoError(t, err)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err = runner.Run(nil, newTaskSupport(withContainerRuntime(runtime), withContext(ctx)))
+		assert.True(t, model.IsErrTimeout(err))
+		assert.Len(t, runtime.Started(), 1)
+		assert.Empty(t, runtime.Containers())
+	})
+
+	t.Run("no runtime is a configuration error", func(t *testing.T) {
+		runner, err := NewRunTaskRunner("none", newContainerTask("run", nil, nil))
+		assert.NoError(t, err)
+		_, err = runner.Run(nil, newTaskSupport())
+		assert.True(t, model.IsErrConfiguration(err))
+	})
+
+	t.Run("eventual cleanup requires a delay", func(t *testing.T) {
+		_, err := NewRunTaskRunner("invalid", newContainerTask("run", &model.ContainerLifetime{Cleanup: "eventually"}, nil))
+		assert.True(t, model.IsErrValidation(err))
+	})
+}
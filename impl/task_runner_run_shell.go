@@ -0,0 +1,177 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v3/impl/expr"
+	"github.com/serverlessworkflow/sdk-go/v3/impl/utils"
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+)
+
+// runShell runs the command with the system shell, followed by the arguments. These are passed as positional
+// parameters expanded after the command, so they are never parsed as shell code. On Windows, where cmd has no
+// positional parameters, they are appended to the command line. The environment of the process is the one of the
+// runner, extended with the task's.
+func (r *RunTaskRunner) runShell(input interface{}, taskSupport TaskSupport) (interface{}, error) {
+	command, args, env, err := r.evaluateShell(input, taskSupport)
+	if err != nil {
+		return nil, err
+	}
+
+	if !r.await() {
+		// the process outlives the task, and may outlive the workflow, but not the cancellation of the instance
+		cmd := newShellCommand(detachedContext(taskSupport), command, args, env)
+		if err = cmd.Start(); err != nil {
+			return nil, model.NewErrRuntime(fmt.Errorf("failed to start shell command: %w", err), r.TaskName)
+		}
+		go func() {
+			_ = cmd.Wait()
+		}()
+		return input, nil
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := newShellCommand(taskSupport.GetContext(), command, args, env)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err = cmd.Run(); err != nil {
+		if ctxErr := taskSupport.GetContext().Err(); ctxErr != nil {
+			return nil, newContextErr(ctxErr, r.TaskName)
+		}
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return nil, model.NewErrRuntime(fmt.Errorf("failed to run shell command: %w", err), r.TaskName)
+		}
+		return nil, model.NewErrRuntime(fmt.Errorf("shell command exited with code %d: %s", exitErr.ExitCode(), strings.TrimSpace(stderr.String())), r.TaskName)
+	}
+	return newProcessResult(cmd.ProcessState.ExitCode(), stdout.String(), stderr.String()), nil
+}
+
+// shellWaitDelay bounds how long a cancelled command is waited for before its output is closed.
+const shellWaitDelay = time.Second
+
+func newShellCommand(ctx context.Context, command string, args []string, env []string) *exec.Cmd {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", append([]string{"/C", command}, args...)...)
+	} else {
+		// the arguments follow the command as positional parameters, never parsed as shell code
+		script := strings.TrimRight(command, " \t\r\n") + " \"$@\"\n"
+		cmd = exec.CommandContext(ctx, "sh", append([]string{"-c", script, "sh"}, args...)...)
+	}
+	cmd.Env = append(os.Environ(), env...)
+	// children of a cancelled shell may keep its output open, don't wait for them
+	cmd.WaitDelay = shellWaitDelay
+	return cmd
+}
+
+// evaluateShell evaluates the runtime expressions of the command, its arguments and environment.
+func (r *RunTaskRunner) evaluateShell(input interface{}, taskSupport TaskSupport) (string, []string, []string, error) {
+	shell := r.Task.Run.Shell
+	properties := map[string]interface{}{"command": shell.Command}
+	if shell.Arguments != nil {
+		if list := shell.Arguments.AsSlice(); list != nil {
+			values := make([]interface{}, 0, len(list))
+			for _, arg := range list {
+				values = append(values, arg)
+			}
+			properties["arguments"] = values
+		} else {
+			properties["arguments"] = utils.DeepClone(shell.Arguments.AsMap())
+		}
+	}
+	if len(shell.Environment) > 0 {
+		env := make(map[string]interface{}, len(shell.Environment))
+		for name, value := range shell.Environment {
+			env[name] = value
+		}
+		properties["environment"] = env
+	}
+
+	evaluated, err := expr.TraverseAndEvaluate(properties, input, taskSupport.GetContext())
+	if err != nil {
+		return "", nil, nil, model.NewErrExpression(err, r.TaskName)
+	}
+	properties = evaluated.(map[string]interface{})
+
+	command, ok := properties["command"].(string)
+	if !ok || command == "" {
+		return "", nil, nil, model.NewErrValidation(fmt.Errorf("shell command must evaluate to a non-empty string, got %v", properties["command"]), r.TaskName)
+	}
+
+	var args []string
+	switch arguments := properties["arguments"].(type) {
+	case []interface{}:
+		for _, arg := range arguments {
+			args = append(args, processArgument(arg))
+		}
+	case map[string]interface{}:
+		// named arguments are passed as key=value, sorted for the command line to be stable
+		for _, name := range sortedKeys(arguments) {
+			if value := arguments[name]; value == nil || value == "" {
+				args = append(args, name)
+			} else {
+				args = append(args, name+"="+processArgument(value))
+			}
+		}
+	}
+
+	var env []string
+	if environment, ok := properties["environment"].(map[string]interface{}); ok {
+		for _, name := range sortedKeys(environment) {
+			env = append(env, name+"="+processArgument(environment[name]))
+		}
+	}
+	return command, args, env, nil
+}
+
+// processArgument renders an evaluated value as a process argument, objects and arrays as JSON.
+func processArgument(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case map[string]interface{}, []interface{}:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(encoded)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
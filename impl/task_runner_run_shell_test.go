@@ -0,0 +1,187 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/serverlessworkflow/sdk-go/v3/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func newShellTask(command string, arguments interface{}, await *bool) *model.RunTask {
+	shell := &model.Shell{Command: command}
+	if arguments != nil {
+		shell.Arguments = &model.RunArguments{Value: arguments}
+	}
+	return &model.RunTask{Run: model.RunTaskConfiguration{Shell: shell, Await: await}}
+}
+
+func TestRunTaskRunner_Shell(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell tests rely on sh")
+	}
+
+	t.Run("arguments and environment are evaluated", func(t *testing.T) {
+		workflow, err := parser.FromFile("./testdata/run_shell.yaml")
+		assert.NoError(t, err)
+		runner, err := NewDefaultRunner(workflow)
+		assert.NoError(t, err)
+
+		output, err := runner.Run(map[string]interface{}{"name": "Rex", "place": "the store"})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"code": 0, "stdout": "Hello Rex, welcome to the store", "stderr": ""}, output)
+	})
+
+	t.Run("named arguments are passed as key=value", func(t *testing.T) {
+		runner, err := NewRunTaskRunner("echo", newShellTask("echo", map[string]interface{}{"--verbose": nil, "--count": "${ .count }"}, nil))
+		assert.NoError(t, err)
+		output, err := runner.Run(map[string]interface{}{"count": 3}, newTaskSupport())
+		assert.NoError(t, err)
+		assert.Equal(t, "--count=3 --verbose\n", output.(map[string]interface{})["stdout"])
+	})
+
+	t.Run("arguments follow the command", func(t *testing.T) {
+		runner, err := NewRunTaskRunner("echo", newShellTask("printf '%s|'\n", []string{"a; echo injected", "$HOME"}, nil))
+		assert.NoError(t, err)
+		output, err := runner.Run(nil, newTaskSupport())
+		assert.NoError(t, err)
+		assert.Equal(t, "a; echo injected|$HOME|", output.(map[string]interface{})["stdout"])
+	})
+
+	t.Run("command runs as given", func(t *testing.T) {
+		runner, err := NewRunTaskRunner("echo", newShellTask(`echo first | tr a-z A-Z; echo "$2" # comment`, []string{"one", "two"}, nil))
+		assert.NoError(t, err)
+		output, err := runner.Run(nil, newTaskSupport())
+		assert.NoError(t, err)
+		assert.Equal(t, "FIRST\ntwo\n", output.(map[string]interface{})["stdout"])
+	})
+
+	t.Run("non-zero exit is a runtime error", func(t *testing.T) {
+		runner, err := NewRunTaskRunner("fail", newShellTask("echo oops >&2; exit 3", nil, nil))
+		assert.NoError(t, err)
+		_, err = runner.Run(nil, newTaskSupport())
+		assert.True(t, model.IsErrRuntime(err))
+		assert.Contains(t, err.Error(), "exited with code 3: oops")
+	})
+
+	t.Run("not awaited process runs in the background", func(t *testing.T) {
+		marker := filepath.Join(t.TempDir(), "done")
+		await := false
+		runner, err := NewRunTaskRunner("background", newShellTask(`sleep 0.1; touch "$1"`, []string{marker}, &await))
+		assert.NoError(t, err)
+
+		input := map[string]interface{}{"id": 1}
+		output, err := runner.Run(input, newTaskSupport())
+		assert.NoError(t, err)
+		assert.Equal(t, input, output)
+		_, err = os.Stat(marker)
+		assert.True(t, os.IsNotExist(err))
+		assert.Eventually(t, func() bool {
+			_, err := os.Stat(marker)
+			return err == nil
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("not awaited process outlives the workflow", func(t *testing.T) {
+		marker := filepath.Join(t.TempDir(), "done")
+		workflow, err := parser.FromYAMLSource([]byte(`
+document:
+  dsl: '1.0.0'
+  namespace: test
+  name: run-shell-outlive
+  version: '1.0.0'
+do:
+  - background:
+      run:
+        shell:
+          command: 'sleep 0.1; touch "$1"'
+          arguments:
+            - ` + marker + `
+        await: false
+`))
+		assert.NoError(t, err)
+		runner, err := NewDefaultRunner(workflow)
+		assert.NoError(t, err)
+		_, err = runner.Run(nil)
+		assert.NoError(t, err)
+		assert.Eventually(t, func() bool {
+			_, err := os.Stat(marker)
+			return err == nil
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("not awaited process is killed when the instance is cancelled", func(t *testing.T) {
+		pidFile := filepath.Join(t.TempDir(), "pid")
+		workflow, err := parser.FromYAMLSource([]byte(`
+document:
+  dsl: '1.0.0'
+  namespace: test
+  name: run-shell-background
+  version: '1.0.0'
+do:
+  - background:
+      run:
+        shell:
+          command: 'echo $$ > "$1"; exec sleep 30'
+          arguments:
+            - ` + pidFile + `
+        await: false
+  - wait:
+      wait:
+        seconds: 30
+`))
+		assert.NoError(t, err)
+		runner, err := NewDefaultRunner(workflow)
+		assert.NoError(t, err)
+		handle, err := runner.Start(context.Background(), nil)
+		assert.NoError(t, err)
+
+		var pid int
+		assert.Eventually(t, func() bool {
+			content, err := os.ReadFile(pidFile)
+			if err != nil {
+				return false
+			}
+			pid, err = strconv.Atoi(strings.TrimSpace(string(content)))
+			return err == nil
+		}, 5*time.Second, 10*time.Millisecond)
+		assert.NoError(t, handle.Cancel())
+		_, err = handle.Wait(context.Background())
+		assert.Error(t, err)
+		assert.Eventually(t, func() bool {
+			process, err := os.FindProcess(pid)
+			return err != nil || process.Signal(syscall.Signal(0)) != nil
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("cancellation kills the process", func(t *testing.T) {
+		runner, err := NewRunTaskRunner("sleep", newShellTask("sleep 10", nil, nil))
+		assert.NoError(t, err)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err = runner.Run(nil, newTaskSupport(withContext(ctx)))
+		assert.True(t, model.IsErrTimeout(err))
+	})
+}
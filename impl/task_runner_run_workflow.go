@@ -0,0 +1,103 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/serverlessworkflow/sdk-go/v3/impl/expr"
+	"github.com/serverlessworkflow/sdk-go/v3/impl/utils"
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+)
+
+// maxWorkflowRunDepth bounds the nesting of the instances run by `run: workflow` tasks, as the workflows of a
+// registry may run themselves, directly or through other workflows.
+const maxWorkflowRunDepth = 64
+
+// workflowRunDepthKey is the key of the depth of the nested instances in the context of the tasks.
+type workflowRunDepthKey struct{}
+
+// runWorkflow runs the referenced workflow as a nested instance. Its input is the evaluated `input` of the task,
+// or the task input when there is none, and its output the task output.
+func (r *RunTaskRunner) runWorkflow(input interface{}, taskSupport TaskSupport) (interface{}, error) {
+	registry := configOf(taskSupport).GetWorkflowRegistry()
+	if registry == nil {
+		return nil, model.NewErrConfiguration(fmt.Errorf("no workflow registry configured to run task %s", r.TaskName), r.TaskName)
+	}
+	reference := r.Task.Run.Workflow
+	workflow, err := registry.Get(reference.Namespace, reference.Name, reference.Version)
+	if err != nil {
+		return nil, model.NewErrConfiguration(fmt.Errorf("failed to resolve the workflow of task %s: %w", r.TaskName, err), r.TaskName)
+	}
+
+	// the nested instance must not share data with this one, which it may run alongside
+	childInput := utils.DeepCloneValue(input)
+	if reference.Input != nil {
+		if childInput, err = expr.TraverseAndEvaluate(utils.DeepClone(reference.Input), input, taskSupport.GetContext()); err != nil {
+			return nil, model.NewErrExpression(err, r.TaskName)
+		}
+	}
+
+	key := registryKey(reference.Namespace, reference.Name, reference.Version)
+	depth, _ := taskSupport.GetContext().Value(workflowRunDepthKey{}).(int)
+	if depth >= maxWorkflowRunDepth {
+		return nil, model.NewErrConfiguration(fmt.Errorf("workflows nest deeper than %d, %s may run itself endlessly", maxWorkflowRunDepth, key), r.TaskName)
+	}
+
+	if !r.await() {
+		// the nested instance outlives the task, and may outlive the workflow, but not the cancellation of the instance
+		detached := taskSupport.WithContext(context.WithValue(detachedContext(taskSupport), workflowRunDepthKey{}, depth+1))
+		child, err := detached.NewSubWorkflowRunner(workflow)
+		if err != nil {
+			return nil, err
+		}
+		reportErr := configOf(taskSupport).LifecyclePublisher == nil
+		go func() {
+			// the failure of the nested instance is published by its workflow faulted lifecycle event, if any
+			if _, err := child.Run(childInput); err != nil && reportErr {
+				log.Printf("workflow %s run by task %s failed: %v", key, r.TaskName, err)
+			}
+		}()
+		return input, nil
+	}
+
+	taskSupport = taskSupport.WithContext(context.WithValue(taskSupport.GetContext(), workflowRunDepthKey{}, depth+1))
+	child, err := taskSupport.NewSubWorkflowRunner(workflow)
+	if err != nil {
+		return nil, err
+	}
+	output, err := child.Run(childInput)
+	if err != nil {
+		return nil, r.subWorkflowErr(err)
+	}
+	return output, nil
+}
+
+// subWorkflowErr raises the error of a nested instance from this task, keeping its type and status so it can be
+// caught like any error of the parent workflow.
+func (r *RunTaskRunner) subWorkflowErr(err error) error {
+	reference := r.Task.Run.Workflow
+	childErr := model.AsError(err)
+	if childErr == nil {
+		return model.NewErrRuntime(fmt.Errorf("workflow %s failed: %w", registryKey(reference.Namespace, reference.Name, reference.Version), err), r.TaskName)
+	}
+	propagated := *childErr
+	propagated.Detail = model.NewStringOrRuntimeExpr(fmt.Sprintf("workflow %s failed at '%s': %s",
+		registryKey(reference.Namespace, reference.Name, reference.Version), childErr.Instance, childErr.Detail))
+	propagated.Instance = &model.JsonPointerOrRuntimeExpression{Value: r.TaskName}
+	return &propagated
+}
@@ -0,0 +1,181 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v3/impl/events"
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/serverlessworkflow/sdk-go/v3/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRunWorkflowTask(name string, input map[string]interface{}, await *bool) *model.RunTask {
+	return &model.RunTask{Run: model.RunTaskConfiguration{
+		Await:    await,
+		Workflow: &model.RunWorkflow{Namespace: "test", Name: name, Version: "1.0.0", Input: input},
+	}}
+}
+
+func TestRunTaskRunner_Workflow(t *testing.T) {
+	registry, err := NewDirectoryWorkflowRegistry("./testdata/workflows")
+	assert.NoError(t, err)
+
+	t.Run("child runs with the evaluated input", func(t *testing.T) {
+		workflow, err := parser.FromFile("./testdata/run_workflow.yaml")
+		assert.NoError(t, err)
+		runner, err := NewDefaultRunner(workflow, WithWorkflowRegistry(registry))
+		assert.NoError(t, err)
+
+		output, err := runner.Run(map[string]interface{}{"owner": "Alice"})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"greeting": "Hello Alice"}, output)
+	})
+
+	t.Run("child instance is correlated with its parent", func(t *testing.T) {
+		child, err := registry.Get("test", "greet", "1.0.0")
+		assert.NoError(t, err)
+		parent := newTaskSupport(withWorkflowRegistry(registry))
+		childRunner, err := parent.NewSubWorkflowRunner(child)
+		assert.NoError(t, err)
+
+		parentID := parent.(*workflowRunnerImpl).RunnerCtx.GetInstanceID()
+		assert.NotEmpty(t, parentID)
+		assert.Equal(t, parentID, childRunner.GetWorkflowCtx().GetParentInstanceID())
+		assert.NotEqual(t, parentID, childRunner.GetWorkflowCtx().GetInstanceID())
+	})
+
+	t.Run("child errors are raised from the task", func(t *testing.T) {
+		runner, err := NewRunTaskRunner("callFail", newRunWorkflowTask("fail", nil, nil))
+		assert.NoError(t, err)
+		_, err = runner.Run(nil, newTaskSupport(withWorkflowRegistry(registry)))
+		assert.True(t, model.IsErrCommunication(err))
+		assert.Equal(t, "callFail", model.AsError(err).Instance.String())
+		assert.Contains(t, err.Error(), "workflow test/fail:1.0.0 failed at '/do/0/fail'")
+	})
+
+	t.Run("not awaited child runs in the background", func(t *testing.T) {
+		publisher := events.NewMemoryPublisher()
+		await := false
+		runner, err := NewRunTaskRunner("notify", newRunWorkflowTask("notify", nil, &await))
+		assert.NoError(t, err)
+
+		input := map[string]interface{}{"id": 1}
+		output, err := runner.Run(input, newTaskSupport(withWorkflowRegistry(registry), withEventPublisher(publisher)))
+		assert.NoError(t, err)
+		assert.Equal(t, input, output)
+		assert.Eventually(t, func() bool { return len(publisher.Events()) == 1 }, time.Second, time.Millisecond)
+		assert.Equal(t, input, publisher.Events()[0].Data)
+	})
+
+	t.Run("not awaited child is cancelled along with the instance", func(t *testing.T) {
+		sleeper, err := parser.FromYAMLSource([]byte(`
+document:
+  dsl: '1.0.0'
+  namespace: test
+  name: sleep
+  version: '1.0.0'
+do:
+  - sleep:
+      wait:
+        seconds: 30
+`))
+		assert.NoError(t, err)
+		workflow, err := parser.FromYAMLSource([]byte(`
+document:
+  dsl: '1.0.0'
+  namespace: test
+  name: background
+  version: '1.0.0'
+do:
+  - background:
+      run:
+        workflow:
+          namespace: test
+          name: sleep
+          version: '1.0.0'
+        await: false
+  - sleep:
+      wait:
+        seconds: 30
+`))
+		assert.NoError(t, err)
+		publisher := events.NewMemoryPublisher()
+		runner, err := NewDefaultRunner(workflow, WithWorkflowRegistry(NewMemoryWorkflowRegistry(sleeper)), WithLifecycleEventPublisher(publisher))
+		assert.NoError(t, err)
+		handle, err := runner.Start(context.Background(), nil)
+		assert.NoError(t, err)
+		countEvents := func(eventType string) int {
+			count := 0
+			for _, event := range publisher.Events() {
+				if event.Type == eventType {
+					count++
+				}
+			}
+			return count
+		}
+		assert.Eventually(t, func() bool {
+			return countEvents(LifecycleEventType("workflow", "started")) == 2
+		}, time.Second, time.Millisecond)
+
+		assert.NoError(t, handle.Cancel())
+		_, err = handle.Wait(context.Background())
+		assert.Error(t, err)
+		assert.Eventually(t, func() bool {
+			return countEvents(LifecycleEventType("workflow", "cancelled")) == 2
+		}, time.Second, time.Millisecond)
+	})
+
+	t.Run("workflows running themselves are stopped", func(t *testing.T) {
+		recursive, err := parser.FromYAMLSource([]byte(`
+document:
+  dsl: '1.0.0'
+  namespace: test
+  name: recursive
+  version: '1.0.0'
+do:
+  - again:
+      run:
+        workflow:
+          namespace: test
+          name: recursive
+          version: '1.0.0'
+`))
+		assert.NoError(t, err)
+		runner, err := NewDefaultRunner(recursive, WithWorkflowRegistry(NewMemoryWorkflowRegistry(recursive)))
+		assert.NoError(t, err)
+		_, err = runner.Run(nil)
+		assert.True(t, model.IsErrConfiguration(err))
+		assert.ErrorContains(t, err, "workflows nest deeper than 64")
+	})
+
+	t.Run("unknown workflow is a configuration error", func(t *testing.T) {
+		runner, err := NewRunTaskRunner("missing", newRunWorkflowTask("missing", nil, nil))
+		assert.NoError(t, err)
+		_, err = runner.Run(nil, newTaskSupport(withWorkflowRegistry(registry)))
+		assert.True(t, model.IsErrConfiguration(err))
+		assert.ErrorContains(t, err, ErrWorkflowNotFound.Error())
+	})
+
+	t.Run("no registry is a configuration error", func(t *testing.T) {
+		runner, err := NewRunTaskRunner("none", newRunWorkflowTask("greet", nil, nil))
+		assert.NoError(t, err)
+		_, err = runner.Run(nil, newTaskSupport())
+		assert.True(t, model.IsErrConfiguration(err))
+	})
+}
@@ -0,0 +1,214 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v3/impl/expr"
+	"github.com/serverlessworkflow/sdk-go/v3/impl/utils"
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+)
+
+const tryTaskDefaultCatchAs = "$error"
+
+// the frames of a try task: the instance runs either the `try` list or the `catch.do` one
+const (
+	tryFrameTry = iota
+	tryFrameCatch
+)
+
+func NewTryTaskRunner(taskName string, task *model.TryTask) (*TryTaskRunner, error) {
+	if task == nil || task.Try == nil || task.Catch == nil {
+		return nil, model.NewErrValidation(fmt.Errorf("invalid Try task %s", taskName), taskName)
+	}
+
+	tryRunner, err := NewDoTaskRunner(task.Try)
+	if err != nil {
+		return nil, err
+	}
+	catchRunner, err := NewDoTaskRunner(task.Catch.Do)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TryTaskRunner{
+		Task:        task,
+		TaskName:    taskName,
+		TryRunner:   tryRunner,
+		CatchRunner: catchRunner,
+	}, nil
+}
+
+type TryTaskRunner struct {
+	Task        *model.TryTask
+	TaskName    string
+	TryRunner   *DoTaskRunner
+	CatchRunner *DoTaskRunner
+}
+
+func (t *TryTaskRunner) Run(input interface{}, taskSupport TaskSupport) (interface{}, error) {
+	retry, err := newRetryExecutor(t.Task.Catch.Retry, taskSupport.GetWorkflowDef(), t.TaskName, configOf(taskSupport).GetClock())
+	if err != nil {
+		return nil, err
+	}
+
+	tracker := trackerOf(taskSupport)
+	frame := tracker.pushFrame()
+	defer tracker.popFrame()
+	if frame != nil && frame.Index == tryFrameCatch {
+		// the caught error was restored along with the local variables
+		defer taskSupport.RemoveLocalExprVars(t.catchAs())
+		return t.CatchRunner.Run(frame.Input, taskSupport)
+	}
+
+	for {
+		tracker.setFrame(tryFrameTry, input)
+		attemptStartedAt := configOf(taskSupport).GetClock().Now()
+		output, err := t.TryRunner.Run(utils.DeepCloneValue(input), taskSupport)
+		if err == nil {
+			return output, nil
+		}
+
+		caught := model.AsError(err)
+		if caught == nil || !matchesErrorFilter(caught, t.Task.Catch.Errors.With) {
+			return nil, err
+		}
+
+		errVar := t.catchAs()
+		taskSupport.AddLocalExprVars(map[string]interface{}{errVar: errorToValue(caught)})
+		output, retryAfter, again, err := t.handle(err, attemptStartedAt, retry, input, taskSupport)
+		taskSupport.RemoveLocalExprVars(errVar)
+		if !again {
+			return output, err
+		}
+
+		if err := sleep(taskSupport.GetContext(), configOf(taskSupport).GetClock(), retryAfter); err != nil {
+			return nil, newContextErr(err, t.TaskName)
+		}
+		taskSupport.SetTaskRetried(t.TaskName)
+	}
+}
+
+// handle deals with an error that passed the catch filter, returning the delay after which the try list must run
+// again, or the task result otherwise. Once retries are exhausted, the error is handled by `catch.do` if any.
+func (t *TryTaskRunner) handle(err error, attemptStartedAt time.Time, retry *retryExecutor, input interface{}, taskSupport TaskSupport) (interface{}, time.Duration, bool, error) {
+	shouldCatch, evalErr := evaluateWhenExceptWhen(t.Task.Catch.When, t.Task.Catch.ExceptWhen, input, taskSupport, t.TaskName)
+	if evalErr != nil {
+		return nil, 0, false, evalErr
+	}
+	if !shouldCatch {
+		return nil, 0, false, err
+	}
+
+	if retry != nil {
+		delay, ok, retryErr := retry.nextDelay(attemptStartedAt, input, taskSupport)
+		if retryErr != nil {
+			return nil, 0, false, retryErr
+		}
+		if ok {
+			return nil, delay, true, nil
+		}
+		if t.Task.Catch.Do == nil {
+			return nil, 0, false, err
+		}
+	}
+
+	trackerOf(taskSupport).setFrame(tryFrameCatch, input)
+	output, err := t.CatchRunner.Run(input, taskSupport)
+	return output, 0, false, err
+}
+
+// evaluateWhenExceptWhen evaluates the `when` and `exceptWhen` guards of a catch or retry policy:
+// the guard passes when `when` is true, or absent, and `exceptWhen` is false, or absent.
+func evaluateWhenExceptWhen(when, exceptWhen *model.RuntimeExpression, input interface{}, taskSupport TaskSupport, taskName string) (bool, error) {
+	if when != nil {
+		result, err := expr.TraverseAndEvaluateBool(model.NormalizeExpr(when.String()), input, taskSupport.GetContext())
+		if err != nil {
+			return false, model.NewErrExpression(err, taskName)
+		}
+		if !result {
+			return false, nil
+		}
+	}
+	if exceptWhen != nil {
+		result, err := expr.TraverseAndEvaluateBool(model.NormalizeExpr(exceptWhen.String()), input, taskSupport.GetContext())
+		if err != nil {
+			return false, model.NewErrExpression(err, taskName)
+		}
+		if result {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (t *TryTaskRunner) catchAs() string {
+	as := strings.TrimSpace(t.Task.Catch.As)
+	if as == "" {
+		return tryTaskDefaultCatchAs
+	}
+	if !strings.HasPrefix(as, "$") {
+		as = "$" + as
+	}
+	return as
+}
+
+func (t *TryTaskRunner) GetTaskName() string {
+	return t.TaskName
+}
+
+// matchesErrorFilter checks every property set in the filter against the error. An empty filter matches any error.
+func matchesErrorFilter(err *model.Error, filter *model.ErrorFilter) bool {
+	if filter == nil {
+		return true
+	}
+	if filter.Type != "" && (err.Type == nil || !strings.EqualFold(filter.Type, err.Type.String())) {
+		return false
+	}
+	if filter.Status != 0 && filter.Status != err.Status {
+		return false
+	}
+	if filter.Instance != "" && (err.Instance == nil || filter.Instance != err.Instance.String()) {
+		return false
+	}
+	if filter.Title != "" && (err.Title == nil || filter.Title != err.Title.String()) {
+		return false
+	}
+	if filter.Details != "" && (err.Detail == nil || filter.Details != err.Detail.String()) {
+		return false
+	}
+	return true
+}
+
+// errorToValue converts the error to the plain object exposed to expressions, e.g. `$error.status`.
+func errorToValue(err *model.Error) map[string]interface{} {
+	value := map[string]interface{}{"status": err.Status}
+	if err.Type != nil {
+		value["type"] = err.Type.String()
+	}
+	if err.Title != nil {
+		value["title"] = err.Title.String()
+	}
+	if err.Detail != nil {
+		value["detail"] = err.Detail.String()
+	}
+	if err.Instance != nil {
+		value["instance"] = err.Instance.String()
+	}
+	return value
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTryTaskRunner_Run(t *testing.T) {
+	t.Run("matching error is exposed to catch.do", func(t *testing.T) {
+		runWorkflowTest(t, "./testdata/try_catch_match.yaml",
+			map[string]interface{}{"orderId": 1},
+			map[string]interface{}{"orderId": 1, "failedWith": 400, "reason": "order 1 is invalid"})
+	})
+
+	t.Run("error not matching the filter propagates", func(t *testing.T) {
+		runWorkflowWithErr(t, "./testdata/try_catch_not_match.yaml", nil, nil, func(err error) {
+			assert.True(t, model.IsErrAuthorization(err))
+			assert.Equal(t, "not allowed", model.AsError(err).Detail.String())
+		})
+	})
+
+	t.Run("when and exceptWhen decide whether the error is caught", func(t *testing.T) {
+		runWorkflowTest(t, "./testdata/try_catch_when.yaml",
+			map[string]interface{}{"fatal": false},
+			map[string]interface{}{"recovered": "Runtime Error"})
+		runWorkflowWithErr(t, "./testdata/try_catch_when.yaml",
+			map[string]interface{}{"fatal": true}, nil, func(err error) {
+				assert.True(t, model.IsErrRuntime(err))
+			})
+	})
+}
+
+func TestMatchesErrorFilter(t *testing.T) {
+	err := model.NewErrCommunication(errors.New("connection refused"), "/do/0/callPets")
+
+	assert.True(t, matchesErrorFilter(err, nil))
+	assert.True(t, matchesErrorFilter(err, &model.ErrorFilter{}))
+	assert.True(t, matchesErrorFilter(err, &model.ErrorFilter{
+		Type:     model.ErrorTypeCommunication,
+		Status:   500,
+		Instance: "/do/0/callPets",
+		Title:    "Communication Error",
+		Details:  "connection refused",
+	}))
+	assert.False(t, matchesErrorFilter(err, &model.ErrorFilter{Status: 503}))
+	assert.False(t, matchesErrorFilter(err, &model.ErrorFilter{Type: model.ErrorTypeTimeout}))
+	assert.False(t, matchesErrorFilter(err, &model.ErrorFilter{Details: "timeout"}))
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v3/impl/ctx"
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+)
+
+func NewWaitTaskRunner(taskName string, task *model.WaitTask) (*WaitTaskRunner, error) {
+	if task == nil || task.Wait == nil {
+		return nil, model.NewErrValidation(fmt.Errorf("invalid Wait task %s", taskName), taskName)
+	}
+	delay, err := task.Wait.AsTimeDuration()
+	if err != nil {
+		return nil, model.NewErrValidation(fmt.Errorf("invalid duration for Wait task %s: %w", taskName, err), taskName)
+	}
+	return &WaitTaskRunner{
+		Task:     task,
+		TaskName: taskName,
+		Delay:    delay,
+	}, nil
+}
+
+type WaitTaskRunner struct {
+	Task     *model.WaitTask
+	TaskName string
+	Delay    time.Duration
+}
+
+// Run blocks until the delay elapses, keeping the task in the waiting status meanwhile. The input is the output.
+func (w *WaitTaskRunner) Run(input interface{}, taskSupport TaskSupport) (interface{}, error) {
+	taskSupport.SetTaskStatus(w.TaskName, ctx.WaitingStatus)
+	if err := sleep(taskSupport.GetContext(), configOf(taskSupport).GetClock(), w.Delay); err != nil {
+		return nil, newContextErr(err, w.TaskName)
+	}
+	taskSupport.SetTaskStatus(w.TaskName, ctx.RunningStatus)
+	return input, nil
+}
+
+func (w *WaitTaskRunner) GetTaskName() string {
+	return w.TaskName
+}
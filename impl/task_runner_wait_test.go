@@ -0,0 +1,88 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v3/impl/ctx"
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/serverlessworkflow/sdk-go/v3/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+// statusRecorder keeps the task status transitions reported through the TaskSupport.
+type statusRecorder struct {
+	TaskSupport
+	statuses []ctx.StatusPhase
+}
+
+func (s *statusRecorder) SetTaskStatus(task string, status ctx.StatusPhase) {
+	s.statuses = append(s.statuses, status)
+	s.TaskSupport.SetTaskStatus(task, status)
+}
+
+func (s *statusRecorder) config() *runnerConfig {
+	return configOf(s.TaskSupport)
+}
+
+func TestWaitTaskRunner_Run(t *testing.T) {
+	t.Run("waits for the delay in the waiting status", func(t *testing.T) {
+		clock := newFakeClock()
+		taskSupport := &statusRecorder{TaskSupport: newTaskSupport(withClock(clock))}
+		runner, err := NewWaitTaskRunner("wait", &model.WaitTask{Wait: model.NewDurationExpr("PT5S")})
+		assert.NoError(t, err)
+
+		input := map[string]interface{}{"key": "value"}
+		output, err := runner.Run(input, taskSupport)
+		assert.NoError(t, err)
+		assert.Equal(t, input, output)
+		assert.Equal(t, []time.Duration{5 * time.Second}, clock.sleeps)
+		assert.Equal(t, []ctx.StatusPhase{ctx.WaitingStatus, ctx.RunningStatus}, taskSupport.statuses)
+	})
+
+	t.Run("returns immediately when the context is cancelled", func(t *testing.T) {
+		cancelled, cancel := context.WithCancel(context.Background())
+		cancel()
+		runner, err := NewWaitTaskRunner("wait", &model.WaitTask{Wait: model.NewDurationExpr("PT1H")})
+		assert.NoError(t, err)
+
+		started := time.Now()
+		_, err = runner.Run(nil, newTaskSupport(withContext(cancelled)))
+		assert.True(t, model.IsErrRuntime(err))
+		assert.ErrorContains(t, err, "cancelled")
+		assert.Less(t, time.Since(started), time.Second)
+	})
+
+	t.Run("invalid duration", func(t *testing.T) {
+		_, err := NewWaitTaskRunner("wait", &model.WaitTask{Wait: model.NewDurationExpr("P1Y")})
+		assert.True(t, model.IsErrValidation(err))
+	})
+}
+
+func TestWaitTaskRunner_Workflow(t *testing.T) {
+	workflow, err := parser.FromFile("./testdata/wait_duration.yaml")
+	assert.NoError(t, err)
+
+	clock := newFakeClock()
+	runner, err := NewDefaultRunner(workflow, WithClock(clock))
+	assert.NoError(t, err)
+	output, err := runner.Run(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"waited": true}, output)
+	assert.Equal(t, []time.Duration{2500 * time.Millisecond, time.Minute}, clock.sleeps)
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+)
+
+// resolveTimeout returns the duration of an inline timeout or of the one named in `use.timeouts`.
+func resolveTimeout(ref *model.TimeoutOrReference, workflow *model.Workflow, instance string) (time.Duration, error) {
+	timeout := ref.Timeout
+	if ref.Reference != nil {
+		if workflow != nil && workflow.Use != nil {
+			timeout = workflow.Use.Timeouts[*ref.Reference]
+		}
+		if timeout == nil {
+			return 0, model.NewErrConfiguration(fmt.Errorf("timeout '%s' is not defined in 'use.timeouts'", *ref.Reference), instance)
+		}
+	}
+	if timeout == nil || timeout.After == nil {
+		return 0, model.NewErrConfiguration(errors.New("timeout must define 'after'"), instance)
+	}
+	after, err := timeout.After.AsTimeDuration()
+	if err != nil {
+		return 0, model.NewErrConfiguration(fmt.Errorf("invalid timeout: %w", err), instance)
+	}
+	return after, nil
+}
+
+// withTimeout binds the TaskSupport to a context that expires after the given timeout, if any.
+// The returned function releases the context and must always be called.
+func withTimeout(ref *model.TimeoutOrReference, taskSupport TaskSupport, instance string) (TaskSupport, context.CancelFunc, error) {
+	if ref == nil {
+		return taskSupport, func() {}, nil
+	}
+	timeout, err := resolveTimeout(ref, taskSupport.GetWorkflowDef(), instance)
+	if err != nil {
+		return nil, nil, err
+	}
+	deadlineCtx, cancel := context.WithTimeout(taskSupport.GetContext(), timeout)
+	return taskSupport.WithContext(deadlineCtx), cancel, nil
+}
+
+// timeoutErr reports err as a timeout when the deadline of the given context was exceeded, since runners
+// interrupted by a deadline may surface it in other forms, e.g. as communication errors.
+func timeoutErr(err error, deadlineCtx context.Context, instance string) error {
+	if err == nil || !errors.Is(deadlineCtx.Err(), context.DeadlineExceeded) || model.IsErrTimeout(err) {
+		return err
+	}
+	return newContextErr(deadlineCtx.Err(), instance)
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeouts(t *testing.T) {
+	t.Run("task timeout can be caught", func(t *testing.T) {
+		started := time.Now()
+		runWorkflowTest(t, "./testdata/timeout_task_caught.yaml", nil, map[string]interface{}{"timedOut": true})
+		assert.Less(t, time.Since(started), 2*time.Second)
+	})
+
+	t.Run("workflow timeout referenced from use.timeouts", func(t *testing.T) {
+		started := time.Now()
+		runWorkflowWithErr(t, "./testdata/timeout_workflow_reference.yaml", nil, nil, func(err error) {
+			assert.True(t, model.IsErrTimeout(err))
+			assert.Equal(t, 408, model.AsError(err).Status)
+		})
+		assert.Less(t, time.Since(started), 2*time.Second)
+	})
+
+	t.Run("fork observes the deadline", func(t *testing.T) {
+		started := time.Now()
+		runWorkflowWithErr(t, "./testdata/timeout_fork.yaml", nil, nil, func(err error) {
+			assert.True(t, model.IsErrTimeout(err))
+		})
+		assert.Less(t, time.Since(started), 2*time.Second)
+	})
+}
+
+func TestResolveTimeout(t *testing.T) {
+	short := "short"
+	missing := "missing"
+	workflow := &model.Workflow{Use: &model.Use{Timeouts: map[string]*model.Timeout{
+		"short": {After: model.NewDurationExpr("PT10S")},
+	}}}
+
+	timeout, err := resolveTimeout(&model.TimeoutOrReference{Reference: &short}, workflow, "task")
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Second, timeout)
+
+	timeout, err = resolveTimeout(&model.TimeoutOrReference{Timeout: &model.Timeout{
+		After: &model.Duration{Value: model.DurationInline{Minutes: 1}},
+	}}, workflow, "task")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Minute, timeout)
+
+	_, err = resolveTimeout(&model.TimeoutOrReference{Reference: &missing}, workflow, "task")
+	assert.True(t, model.IsErrConfiguration(err))
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"sync"
+)
+
+// FanOut delivers every published value to all the open subscriptions. It backs the in-process brokers, which
+// subscribers may close while publishers wait for room in their buffer.
+type FanOut[T any] struct {
+	mu            sync.RWMutex
+	buffer        int
+	subscriptions map[*FanOutSubscription[T]]struct{}
+}
+
+// NewFanOut creates a FanOut whose subscriptions hold up to buffer values before publishing blocks.
+func NewFanOut[T any](buffer int) *FanOut[T] {
+	return &FanOut[T]{buffer: buffer, subscriptions: map[*FanOutSubscription[T]]struct{}{}}
+}
+
+// Publish delivers the value to every open subscription, waiting for room in the ones that are full. Subscriptions
+// closed meanwhile are skipped.
+func (f *FanOut[T]) Publish(ctx context.Context, value T) error {
+	f.mu.RLock()
+	subscriptions := make([]*FanOutSubscription[T], 0, len(f.subscriptions))
+	for subscription := range f.subscriptions {
+		subscriptions = append(subscriptions, subscription)
+	}
+	f.mu.RUnlock()
+	for _, subscription := range subscriptions {
+		if err := subscription.deliver(ctx, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Subscribe opens a subscription receiving the values published from now on.
+func (f *FanOut[T]) Subscribe() *FanOutSubscription[T] {
+	subscription := &FanOutSubscription[T]{fanOut: f, values: make(chan T, f.buffer), done: make(chan struct{})}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.subscriptions[subscription] = struct{}{}
+	return subscription
+}
+
+// Subscribers returns the number of open subscriptions.
+func (f *FanOut[T]) Subscribers() int {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return len(f.subscriptions)
+}
+
+// FanOutSubscription is an open stream of the values published to a FanOut.
+type FanOutSubscription[T any] struct {
+	fanOut *FanOut[T]
+	values chan T
+	// done is closed first on Close, releasing the publishers waiting for room, then values once they are gone
+	done chan struct{}
+	mu   sync.RWMutex
+	once sync.Once
+}
+
+// Values returns the channel of the published values, closed once the subscription is.
+func (s *FanOutSubscription[T]) Values() <-chan T {
+	return s.values
+}
+
+func (s *FanOutSubscription[T]) deliver(ctx context.Context, value T) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	select {
+	case <-s.done:
+		return nil
+	default:
+	}
+	select {
+	case s.values <- value:
+	case <-s.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return nil
+}
+
+// Close stops the subscription. It may be called more than once, and while values are being published.
+func (s *FanOutSubscription[T]) Close() {
+	s.once.Do(func() {
+		close(s.done)
+		s.fanOut.mu.Lock()
+		delete(s.fanOut.subscriptions, s)
+		s.fanOut.mu.Unlock()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		close(s.values)
+	})
+}
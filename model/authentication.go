@@ -185,3 +185,39 @@ type OpenIdConnectAuthenticationPolicy struct {
 	Properties *OAuth2AuthenticationProperties `json:",omitempty" validate:"omitempty,required_without=Use"`
 	Use        string                          `json:"use,omitempty" validate:"omitempty,required_without=Properties"`
 }
+
+func (o *OpenIdConnectAuthenticationPolicy) UnmarshalJSON(data []byte) error {
+	temp := struct {
+		Use string `json:"use"`
+	}{}
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+	o.Use = temp.Use
+
+	// Properties are inlined, the same way they are for OAuth2AuthenticationPolicy
+	if containsOAuth2Properties(data) {
+		o.Properties = &OAuth2AuthenticationProperties{}
+		if err := json.Unmarshal(data, o.Properties); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *OpenIdConnectAuthenticationPolicy) MarshalJSON() ([]byte, error) {
+	result := make(map[string]interface{})
+	if o.Properties != nil {
+		propertiesJSON, err := json.Marshal(o.Properties)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(propertiesJSON, &result); err != nil {
+			return nil, err
+		}
+	}
+	if o.Use != "" {
+		result["use"] = o.Use
+	}
+	return json.Marshal(result)
+}
@@ -162,3 +162,37 @@ func TestAuthenticationOAuth2Policy(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthenticationOIDCPolicy(t *testing.T) {
+	input := `{
+		"oidc": {
+			"authority": "https://auth.example.com",
+			"grant": "client_credentials",
+			"client": {"id": "workflow", "secret": "s3cr3t"},
+			"scopes": ["openid"]
+		}
+	}`
+
+	var authPolicy AuthenticationPolicy
+	if err := json.Unmarshal([]byte(input), &authPolicy); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if authPolicy.OIDC == nil || authPolicy.OIDC.Properties == nil {
+		t.Fatalf("Expected inline OIDC properties to be set")
+	}
+	if authPolicy.OIDC.Properties.Authority.String() != "https://auth.example.com" || authPolicy.OIDC.Properties.Client.ID != "workflow" {
+		t.Errorf("Unexpected OIDC properties: %+v", authPolicy.OIDC.Properties)
+	}
+	if err := validate.Struct(authPolicy); err != nil {
+		t.Errorf("Unexpected validation error: %v", err)
+	}
+
+	marshaled, err := json.Marshal(authPolicy)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	expected := `{"oidc":{"authority":"https://auth.example.com","client":{"id":"workflow","secret":"s3cr3t"},"grant":"client_credentials","scopes":["openid"]}}`
+	if string(marshaled) != expected {
+		t.Errorf("Expected %s but got %s", expected, marshaled)
+	}
+}
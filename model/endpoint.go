@@ -96,7 +96,7 @@ func (u *LiteralUri) GetValue() interface{} {
 
 type EndpointConfiguration struct {
 	RuntimeExpression *RuntimeExpression                 `json:"-"`
-	URI               URITemplate                        `json:"uri" validate:"required"`
+	URI               URITemplate                        `json:"uri" validate:"required_without=RuntimeExpression"`
 	Authentication    *ReferenceableAuthenticationPolicy `json:"authentication,omitempty"`
 }
 
@@ -123,7 +123,7 @@ func (e *EndpointConfiguration) UnmarshalJSON(data []byte) error {
 	}
 
 	var runtimeExpr RuntimeExpression
-	if err := json.Unmarshal(temp.URI, &runtimeExpr); err == nil && runtimeExpr.IsValid() {
+	if err := json.Unmarshal(temp.URI, &runtimeExpr); err == nil && IsStrictExpr(runtimeExpr.Value) {
 		e.RuntimeExpression = &runtimeExpr
 		return nil
 	}
@@ -184,3 +184,37 @@ func TestEndpoint_MarshalJSON(t *testing.T) {
 		assert.JSONEq(t, `{}`, string(data), "output JSON should be empty")
 	})
 }
+
+func TestEndpointConfiguration_URI(t *testing.T) {
+	t.Run("Runtime expression URI validates", func(t *testing.T) {
+		var endpoint Endpoint
+		err := json.Unmarshal([]byte(`{"uri": "${ .baseUrl + \"/pets\" }"}`), &endpoint)
+
+		assert.NoError(t, err, "Unmarshal should not return an error")
+		assert.Nil(t, endpoint.EndpointConfig.URI, "EndpointConfig URI should not be set")
+		assert.NotNil(t, endpoint.EndpointConfig.RuntimeExpression, "EndpointConfig Expression should be set")
+		assert.NoError(t, validate.Struct(endpoint.EndpointConfig), "a runtime expression satisfies the required URI")
+	})
+
+	t.Run("Missing URI fails validation", func(t *testing.T) {
+		assert.Error(t, validate.Struct(&EndpointConfiguration{}), "either the URI or a runtime expression is required")
+	})
+
+	t.Run("URI template with placeholders is not an expression", func(t *testing.T) {
+		var endpoint Endpoint
+		err := json.Unmarshal([]byte(`{"uri": "http://example.com/{.id}"}`), &endpoint)
+
+		assert.NoError(t, err, "Unmarshal should not return an error")
+		assert.Nil(t, endpoint.EndpointConfig.RuntimeExpression, "EndpointConfig Expression should not be set")
+		assert.Equal(t, &LiteralUriTemplate{Value: "http://example.com/{.id}"}, endpoint.EndpointConfig.URI)
+		assert.NoError(t, validate.Struct(endpoint.EndpointConfig))
+	})
+
+	t.Run("Non-strict expression URI is rejected", func(t *testing.T) {
+		var config EndpointConfiguration
+		err := json.Unmarshal([]byte(`{"uri": ".baseUrl"}`), &config)
+
+		assert.Error(t, err, "only ${} expressions are evaluated as endpoint URIs")
+		assert.Nil(t, config.RuntimeExpression, "EndpointConfig Expression should not be set")
+	})
+}
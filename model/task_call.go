@@ -14,7 +14,10 @@
 
 package model
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"regexp"
+)
 
 type CallHTTP struct {
 	TaskBase `json:",inline"` // Inline TaskBase fields
@@ -132,3 +135,27 @@ type CallFunction struct {
 func (c *CallFunction) GetBase() *TaskBase {
 	return &c.TaskBase
 }
+
+// DefaultCatalog is the name of the runtime's catalog, which needs not be declared in `use.catalogs`.
+const DefaultCatalog = "default"
+
+// catalogFunctionPattern matches `name:version@catalog` calls. The name and version are path segments of the catalog,
+// so they are restricted to characters that cannot escape it, e.g. with `..`.
+var catalogFunctionPattern = regexp.MustCompile(`^([A-Za-z0-9_-][A-Za-z0-9._-]*):([A-Za-z0-9_-][A-Za-z0-9._-]*)@([A-Za-z0-9_-][A-Za-z0-9._-]*)$`)
+
+// CatalogFunctionReference identifies a function version shared in a catalog.
+type CatalogFunctionReference struct {
+	Name    string
+	Version string
+	Catalog string
+}
+
+// AsCatalogReference parses a `name:version@catalog` call, it returns nil when the call references a function
+// declared in `use.functions`.
+func (c *CallFunction) AsCatalogReference() *CatalogFunctionReference {
+	match := catalogFunctionPattern.FindStringSubmatch(c.Call)
+	if match == nil {
+		return nil
+	}
+	return &CatalogFunctionReference{Name: match[1], Version: match[2], Catalog: match[3]}
+}
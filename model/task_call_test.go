@@ -486,3 +486,24 @@ func TestCallFunction_UnmarshalJSON(t *testing.T) {
 	}
 	assert.Equal(t, expectedWith, callFunction.With)
 }
+
+func TestCallFunction_AsCatalogReference(t *testing.T) {
+	tests := []struct {
+		call     string
+		expected *CatalogFunctionReference
+	}{
+		{call: "greet:1.0.0@shared", expected: &CatalogFunctionReference{Name: "greet", Version: "1.0.0", Catalog: "shared"}},
+		{call: "send_mail:v2-beta@default", expected: &CatalogFunctionReference{Name: "send_mail", Version: "v2-beta", Catalog: "default"}},
+		{call: "greet"},
+		{call: "../../etc:1@default"},
+		{call: "greet:../../x@default"},
+		{call: ".hidden:1.0.0@default"},
+		{call: "greet:.1@default"},
+		{call: "pets/greet:1.0.0@default"},
+	}
+	for _, test := range tests {
+		t.Run(test.call, func(t *testing.T) {
+			assert.Equal(t, test.expected, (&CallFunction{Call: test.call}).AsCatalogReference())
+		})
+	}
+}
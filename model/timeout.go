@@ -18,8 +18,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"regexp"
+	"strconv"
+	"time"
 )
 
+// isoDurationPattern captures the components of an ISO 8601 duration, e.g. P1DT2H30M or PT0.5S.
+var isoDurationPattern = regexp.MustCompile(`^P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
 // Timeout specifies a time limit for tasks or workflows.
 type Timeout struct {
 	// After The duration after which to timeout
@@ -73,7 +79,7 @@ func (t *Timeout) MarshalJSON() ([]byte, error) {
 
 // TimeoutOrReference handles either a Timeout definition or a reference (string).
 type TimeoutOrReference struct {
-	Timeout   *Timeout `json:"-" validate:"required_without=Ref"`
+	Timeout   *Timeout `json:"-" validate:"required_without=Reference"`
 	Reference *string  `json:"-" validate:"required_without=Timeout"`
 }
 
@@ -144,6 +150,49 @@ func (d *Duration) AsInline() *DurationInline {
 	}
 }
 
+// AsTimeDuration converts either form of the duration into a time.Duration.
+// ISO 8601 years and months have no fixed length, so they are rejected.
+func (d *Duration) AsTimeDuration() (time.Duration, error) {
+	if d == nil {
+		return 0, nil
+	}
+	switch v := d.Value.(type) {
+	case DurationInline:
+		return v.AsTimeDuration(), nil
+	case DurationExpression:
+		return ParseISO8601Duration(v.Expression)
+	case string:
+		return ParseISO8601Duration(v)
+	default:
+		return 0, errors.New("unknown Duration type")
+	}
+}
+
+// ParseISO8601Duration converts an ISO 8601 duration expression, such as P1DT12H or PT1.5S, into a time.Duration.
+func ParseISO8601Duration(expression string) (time.Duration, error) {
+	matches := isoDurationPattern.FindStringSubmatch(expression)
+	if matches == nil || expression == "P" || expression[len(expression)-1] == 'T' {
+		return 0, fmt.Errorf("invalid ISO 8601 duration '%s'", expression)
+	}
+	if matches[1] != "" || matches[2] != "" {
+		return 0, fmt.Errorf("ISO 8601 duration '%s' uses years or months, which have no fixed length", expression)
+	}
+
+	units := []time.Duration{0, 0, 7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var total time.Duration
+	for i := 3; i < len(matches); i++ {
+		if matches[i] == "" {
+			continue
+		}
+		value, err := strconv.ParseFloat(matches[i], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid ISO 8601 duration '%s': %w", expression, err)
+		}
+		total += time.Duration(value * float64(units[i-1]))
+	}
+	return total, nil
+}
+
 // UnmarshalJSON for Duration to handle both inline and expression durations.
 func (d *Duration) UnmarshalJSON(data []byte) error {
 	var raw map[string]interface{}
@@ -196,6 +245,15 @@ type DurationInline struct {
 	Milliseconds int32 `json:"milliseconds,omitempty"`
 }
 
+// AsTimeDuration sums all the components of the inline duration.
+func (d *DurationInline) AsTimeDuration() time.Duration {
+	return time.Duration(d.Days)*24*time.Hour +
+		time.Duration(d.Hours)*time.Hour +
+		time.Duration(d.Minutes)*time.Minute +
+		time.Duration(d.Seconds)*time.Second +
+		time.Duration(d.Milliseconds)*time.Millisecond
+}
+
 // MarshalJSON for DurationInline.
 func (d *DurationInline) MarshalJSON() ([]byte, error) {
 	return json.Marshal(map[string]interface{}{
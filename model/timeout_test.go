@@ -17,6 +17,7 @@ package model
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -226,3 +227,62 @@ func TestTimeoutOrReference_MarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestDuration_AsTimeDuration(t *testing.T) {
+	tests := []struct {
+		name     string
+		duration *Duration
+		expect   time.Duration
+		err      bool
+	}{
+		{
+			name:     "Inline duration",
+			duration: &Duration{DurationInline{Days: 1, Hours: 2, Minutes: 3, Seconds: 4, Milliseconds: 5}},
+			expect:   26*time.Hour + 3*time.Minute + 4*time.Second + 5*time.Millisecond,
+		},
+		{
+			name:     "ISO 8601 duration",
+			duration: NewDurationExpr("P3DT4H5M6S"),
+			expect:   76*time.Hour + 5*time.Minute + 6*time.Second,
+		},
+		{
+			name:     "ISO 8601 weeks and fractional seconds",
+			duration: NewDurationExpr("P1WT0.25S"),
+			expect:   7*24*time.Hour + 250*time.Millisecond,
+		},
+		{
+			name:     "Nil duration",
+			duration: nil,
+			expect:   0,
+		},
+		{
+			name:     "ISO 8601 months have no fixed length",
+			duration: NewDurationExpr("P1M"),
+			err:      true,
+		},
+		{
+			name:     "Invalid ISO 8601 duration",
+			duration: NewDurationExpr("PT"),
+			err:      true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			duration, err := test.duration.AsTimeDuration()
+			if test.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expect, duration)
+			}
+		})
+	}
+}
+
+func TestTimeoutOrReference_Validation(t *testing.T) {
+	reference := "short"
+	assert.NoError(t, GetValidator().Struct(&TimeoutOrReference{Reference: &reference}))
+	assert.NoError(t, GetValidator().Struct(&TimeoutOrReference{Timeout: &Timeout{After: NewDurationExpr("PT10S")}}))
+	assert.Error(t, GetValidator().Struct(&TimeoutOrReference{}))
+}
@@ -15,9 +15,11 @@
 package model
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 
 	validator "github.com/go-playground/validator/v10"
@@ -76,6 +78,7 @@ func init() {
 
 	registerValidator("switch_item", validateSwitchItem)
 	validate.RegisterStructValidation(validateTaskItem, TaskItem{})
+	validate.RegisterStructValidation(validateWorkflow, Workflow{})
 }
 
 func GetValidator() *validator.Validate {
@@ -137,6 +140,187 @@ func validateTaskItem(sl validator.StructLevel) {
 	}
 }
 
+// validateWorkflow is a struct-level validation function for Workflow checking the references across the definition.
+func validateWorkflow(sl validator.StructLevel) {
+	validateFunctionReferences(sl)
+	validateSecretReferences(sl)
+}
+
+// validateFunctionReferences is a struct-level validation function for Workflow ensuring that every `call` of a
+// custom function references one of the functions declared in `use.functions`, or a function of a declared catalog.
+func validateFunctionReferences(sl validator.StructLevel) {
+	workflow := sl.Current().Interface().(Workflow)
+	var functions NamedTaskMap
+	var catalogs map[string]*Catalog
+	if workflow.Use != nil {
+		functions = workflow.Use.Functions
+		catalogs = workflow.Use.Catalogs
+	}
+
+	check := func(item *TaskItem) {
+		call, ok := item.Task.(*CallFunction)
+		if !ok {
+			return
+		}
+		declared := false
+		if reference := call.AsCatalogReference(); reference != nil {
+			_, declared = catalogs[reference.Catalog]
+			declared = declared || reference.Catalog == DefaultCatalog
+		} else {
+			_, declared = functions[call.Call]
+		}
+		if !declared {
+			sl.ReportError(call.Call, item.Key, "Call", "function_reference", call.Call)
+		}
+	}
+	walkTasks(workflow.Do, check)
+	// the functions of `use.functions` called by each one of them, to find the ones that would call themselves endlessly
+	calls := map[string][]string{}
+	for name, function := range functions {
+		checkAndRecord := func(item *TaskItem) {
+			check(item)
+			if call, ok := item.Task.(*CallFunction); ok && call.AsCatalogReference() == nil {
+				calls[name] = append(calls[name], call.Call)
+			}
+		}
+		checkAndRecord(&TaskItem{Key: name, Task: function})
+		walkTasks(nestedTasks(function), checkAndRecord)
+	}
+	for _, name := range recursiveFunctions(calls) {
+		sl.ReportError(name, name, "Functions", "function_cycle", name)
+	}
+}
+
+// recursiveFunctions returns, sorted, the functions calling themselves, either directly or through other functions.
+func recursiveFunctions(calls map[string][]string) []string {
+	var recursive []string
+	for name := range calls {
+		visited := map[string]bool{}
+		pending := append([]string{}, calls[name]...)
+		for len(pending) > 0 {
+			next := pending[len(pending)-1]
+			pending = pending[:len(pending)-1]
+			if next == name {
+				recursive = append(recursive, name)
+				break
+			}
+			if !visited[next] {
+				visited[next] = true
+				pending = append(pending, calls[next]...)
+			}
+		}
+	}
+	sort.Strings(recursive)
+	return recursive
+}
+
+var (
+	secretExprPattern   = regexp.MustCompile(`\$secrets(?:\.([A-Za-z_][A-Za-z0-9_]*)|\[\s*"([^"]+)"\s*\])`)
+	secretPolicyClasses = map[string]bool{"basic": true, "bearer": true, "digest": true, "oauth2": true, "oidc": true}
+)
+
+// validateSecretReferences ensures that the secrets accessed through `$secrets` in runtime expressions, or used by
+// authentication policies, are declared in `use.secrets`.
+func validateSecretReferences(sl validator.StructLevel) {
+	workflow := sl.Current().Interface().(Workflow)
+	declared := map[string]bool{}
+	if workflow.Use != nil {
+		for _, name := range workflow.Use.Secrets {
+			declared[name] = true
+		}
+	}
+	names, err := SecretReferences(&workflow)
+	if err != nil {
+		sl.ReportError(workflow, "Workflow", "Workflow", "secret_reference", err.Error())
+		return
+	}
+	for _, name := range names {
+		if !declared[name] {
+			sl.ReportError(name, name, "Secrets", "secret_reference", name)
+		}
+	}
+}
+
+// SecretReferences returns, sorted, the secrets that a definition, e.g. a workflow or a task, accesses through
+// `$secrets` in its runtime expressions or uses in its authentication policies.
+func SecretReferences(definition interface{}) ([]string, error) {
+	payload, err := json.Marshal(definition)
+	if err != nil {
+		return nil, err
+	}
+	var tree interface{}
+	if err = json.Unmarshal(payload, &tree); err != nil {
+		return nil, err
+	}
+	referenced := map[string]bool{}
+	var walk func(key string, node interface{})
+	walk = func(key string, node interface{}) {
+		switch v := node.(type) {
+		case string:
+			// only expressions are evaluated, prose such as descriptions may mention secrets freely
+			if IsStrictExpr(strings.TrimSpace(v)) {
+				for _, match := range secretExprPattern.FindAllStringSubmatch(v, -1) {
+					referenced[match[1]+match[2]] = true
+				}
+			}
+		case map[string]interface{}:
+			if use, ok := v["use"].(string); ok && secretPolicyClasses[key] {
+				referenced[use] = true
+			}
+			for k, item := range v {
+				walk(k, item)
+			}
+		case []interface{}:
+			for _, item := range v {
+				walk(key, item)
+			}
+		}
+	}
+	walk("", tree)
+	names := make([]string, 0, len(referenced))
+	for name := range referenced {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// walkTasks visits every task of the list, including the ones nested in composite tasks.
+func walkTasks(list *TaskList, visit func(item *TaskItem)) {
+	if list == nil {
+		return
+	}
+	for _, item := range *list {
+		if item == nil || item.Task == nil {
+			continue
+		}
+		visit(item)
+		walkTasks(nestedTasks(item.Task), visit)
+	}
+}
+
+// nestedTasks returns the tasks nested in a composite task, all the catch tasks of a try task included.
+func nestedTasks(task Task) *TaskList {
+	switch t := task.(type) {
+	case *DoTask:
+		return t.Do
+	case *ForTask:
+		return t.Do
+	case *ForkTask:
+		return t.Fork.Branches
+	case *TryTask:
+		nested := TaskList{}
+		if t.Try != nil {
+			nested = append(nested, *t.Try...)
+		}
+		if t.Catch != nil && t.Catch.Do != nil {
+			nested = append(nested, *t.Catch.Do...)
+		}
+		return &nested
+	}
+	return nil
+}
+
 // validateConcreteTask validates a concrete Task type and reports nested errors.
 func validateConcreteTask(sl validator.StructLevel, task interface{}, fieldName string) {
 	err := validate.Struct(task)
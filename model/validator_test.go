@@ -15,7 +15,10 @@
 package model
 
 import (
+	"encoding/json"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestRegexValidators(t *testing.T) {
@@ -66,3 +69,83 @@ func TestRegexValidators(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateFunctionReferences(t *testing.T) {
+	workflowWith := func(use string, call string) *Workflow {
+		source := `{
+			"document": {"dsl": "1.0.0", "namespace": "test", "name": "functions", "version": "1.0.0"},
+			` + use + `
+			"do": [{"outer": {"try": [{"inner": {"call": "` + call + `"}}], "catch": {}}}]
+		}`
+		workflow := &Workflow{}
+		assert.NoError(t, json.Unmarshal([]byte(source), workflow))
+		return workflow
+	}
+
+	assert.NoError(t, validate.Struct(workflowWith(`"use": {"functions": {"greet": {"set": {"greeting": "hello"}}}},`, "greet")))
+
+	err := validate.Struct(workflowWith("", "greet"))
+	assert.ErrorContains(t, err, "function_reference")
+
+	err = validate.Struct(workflowWith(`"use": {"functions": {"greet": {"call": "missing"}}},`, "greet"))
+	assert.ErrorContains(t, err, "function_reference")
+
+	err = validate.Struct(workflowWith(`"use": {"functions": {"greet": {"call": "greet"}}},`, "greet"))
+	assert.ErrorContains(t, err, "function_cycle")
+
+	err = validate.Struct(workflowWith(`"use": {"functions": {
+		"greet": {"do": [{"welcome": {"call": "welcome"}}]},
+		"welcome": {"try": [{"again": {"call": "greet"}}], "catch": {}}
+	}},`, "greet"))
+	assert.ErrorContains(t, err, "function_cycle")
+
+	assert.NoError(t, validate.Struct(workflowWith(`"use": {"functions": {
+		"greet": {"do": [{"first": {"call": "welcome"}}, {"second": {"call": "welcome"}}]},
+		"welcome": {"set": {"greeting": "welcome"}}
+	}},`, "greet")))
+}
+
+func TestValidateSecretReferences(t *testing.T) {
+	workflowWith := func(secrets string, use string, value string) *Workflow {
+		source := `{
+			"document": {"dsl": "1.0.0", "namespace": "test", "name": "secrets", "version": "1.0.0"},
+			"use": {"secrets": [` + secrets + `], "authentications": {"auth": {"basic": {"use": "` + use + `"}}}},
+			"do": [{"read": {"set": {"value": "` + value + `"}}}]
+		}`
+		workflow := &Workflow{}
+		assert.NoError(t, json.Unmarshal([]byte(source), workflow))
+		return workflow
+	}
+
+	assert.NoError(t, validate.Struct(workflowWith(`"creds", "key"`, "creds", `${ $secrets.key }`)))
+	assert.NoError(t, validate.Struct(workflowWith(`"creds", "key"`, "creds", `${ $secrets[\"key\"] }`)))
+
+	err := validate.Struct(workflowWith(`"creds"`, "creds", `${ $secrets.key }`))
+	assert.ErrorContains(t, err, "secret_reference")
+
+	err = validate.Struct(workflowWith(`"key"`, "creds", `${ $secrets.key }`))
+	assert.ErrorContains(t, err, "secret_reference")
+
+	// only runtime expressions are evaluated
+	assert.NoError(t, validate.Struct(workflowWith(`"creds"`, "creds", `the key is read from $secrets.key`)))
+	prose := &Workflow{}
+	assert.NoError(t, json.Unmarshal([]byte(`{
+		"document": {"dsl": "1.0.0", "namespace": "test", "name": "secrets", "version": "1.0.0",
+			"summary": "Calls the API with $secrets.apiKey", "metadata": {"notes": "$secrets[\"token\"] is rotated daily"}},
+		"do": [{"read": {"set": {"value": "${ $secrets.apiKey }"}}}]
+	}`), prose))
+	err = validate.Struct(prose)
+	assert.ErrorContains(t, err, "secret_reference")
+	assert.NotContains(t, err.Error(), "token")
+}
+
+func TestSecretReferences(t *testing.T) {
+	task := &CallHTTP{Call: "http", With: HTTPArguments{
+		Method:   "get",
+		Endpoint: NewEndpoint("${ \"https://example.com?key=\" + $secrets.apiKey }"),
+		Headers:  map[string]string{"X-Token": "${ $secrets[\"token\"] }", "X-Note": "$secrets.ignored"},
+	}}
+	names, err := SecretReferences(task)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"apiKey", "token"}, names)
+}
@@ -57,6 +57,25 @@ func FromJSONSource(source []byte) (workflow *model.Workflow, err error) {
 	return workflow, nil
 }
 
+// TaskFromYAMLSource parses the given YAML, or JSON, definition of a single task, e.g. a function shared in a
+// catalog, into its Task type.
+func TaskFromYAMLSource(source []byte) (model.Task, error) {
+	jsonBytes, err := yaml.YAMLToJSON(source)
+	if err != nil {
+		return nil, err
+	}
+	const key = "task"
+	tasks := model.NamedTaskMap{}
+	if err = json.Unmarshal([]byte(`{"`+key+`":`+string(jsonBytes)+`}`), &tasks); err != nil {
+		return nil, err
+	}
+	task := tasks[key]
+	if err = model.GetValidator().Struct(model.TaskItem{Key: key, Task: task}); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
 // FromFile parses the given Serverless Workflow file into the Workflow type.
 func FromFile(path string) (*model.Workflow, error) {
 	if err := checkFilePath(path); err != nil {
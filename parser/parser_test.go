@@ -17,6 +17,7 @@ package parser
 import (
 	"testing"
 
+	"github.com/serverlessworkflow/sdk-go/v3/model"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -143,3 +144,17 @@ func TestCheckFilePath(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskFromYAMLSource(t *testing.T) {
+	task, err := TaskFromYAMLSource([]byte(`
+call: http
+with:
+  method: get
+  endpoint: https://petstore.com/pets
+`))
+	assert.NoError(t, err)
+	assert.IsType(t, &model.CallHTTP{}, task)
+
+	_, err = TaskFromYAMLSource([]byte(`call: http`))
+	assert.Error(t, err)
+}